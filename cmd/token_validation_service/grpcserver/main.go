@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Command grpcserver runs the stateless gRPC token validation service.
+//
+// The service validates token requests with the public parameters loaded at startup, one file per TMS:
+//
+//	grpcserver -listen :7080 -ops-listen :7081 \
+//	  -pp mynet:mychannel:token-chaincode=./zkatdlog_pp.json \
+//	  -pp othernet::tokens=./fabtoken_pp.json
+//
+// The ledger values a token request depends on must be passed along with the request.
+// The operations endpoint serves Prometheus metrics at /metrics and liveness at /healthz.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/LFDT-Panurus/panurus/token"
+	"github.com/LFDT-Panurus/panurus/token/core"
+	fabtoken "github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/driver"
+	dlog "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/validation"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// ppFlags collects the repeated -pp flags
+type ppFlags map[token.TMSID]string
+
+func (p ppFlags) String() string {
+	entries := make([]string, 0, len(p))
+	for id, path := range p {
+		entries = append(entries, fmt.Sprintf("%s:%s:%s=%s", id.Network, id.Channel, id.Namespace, path))
+	}
+
+	return strings.Join(entries, ",")
+}
+
+func (p ppFlags) Set(value string) error {
+	id, path, ok := strings.Cut(value, "=")
+	if !ok || len(path) == 0 {
+		return fmt.Errorf("expected network:channel:namespace=path, got [%s]", value)
+	}
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 || len(parts[0]) == 0 || len(parts[2]) == 0 {
+		return fmt.Errorf("expected network:channel:namespace, got [%s]", id)
+	}
+	p[token.TMSID{Network: parts[0], Channel: parts[1], Namespace: parts[2]}] = path
+
+	return nil
+}
+
+func main() {
+	pps := ppFlags{}
+	listen := flag.String("listen", ":7080", "Address the gRPC validation service listens on")
+	opsListen := flag.String("ops-listen", ":7081", "Address the operations endpoint (/metrics, /healthz) listens on, empty to disable")
+	tlsCert := flag.String("tls-cert", "", "TLS certificate file, TLS is disabled if empty")
+	tlsKey := flag.String("tls-key", "", "TLS private key file")
+	maxBatchSize := flag.Int("max-batch-size", validation.DefaultMaxBatchSize, "Maximum number of token requests of a ValidateBatch call")
+	maxRecvMsgSize := flag.Int("max-recv-msg-size", validation.DefaultMaxRecvMsgSize, "Maximum size in bytes of a request message")
+	flag.Var(pps, "pp", "Public parameters of a TMS as network:channel:namespace=path, can be repeated")
+	flag.Parse()
+
+	limits := []validation.ServerOption{validation.WithMaxBatchSize(*maxBatchSize), validation.WithMaxRecvMsgSize(*maxRecvMsgSize)}
+	if err := run(*listen, *opsListen, *tlsCert, *tlsKey, pps, limits); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(listen, opsListen, tlsCert, tlsKey string, pps ppFlags, limits []validation.ServerOption) error {
+	if len(pps) == 0 {
+		return errors.New("no public parameters configured, use -pp")
+	}
+
+	registry := validation.NewRegistry(core.NewValidatorDriverService(
+		fabtoken.NewValidatorDriver(),
+		dlog.NewValidatorDriver(),
+	))
+	for tmsID, path := range pps {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read public parameters for [%s]: %w", tmsID, err)
+		}
+		if err := registry.Register(tmsID, raw); err != nil {
+			return err
+		}
+		fmt.Printf("Loaded public parameters for [%s] from [%s]\n", tmsID, path)
+	}
+
+	server := validation.NewServer(registry, nil, validation.NewMetrics(&prometheus.Provider{}), limits...)
+	opts := server.GRPCOptions()
+	if len(tlsCert) != 0 {
+		creds, err := credentials.NewServerTLSFromFile(tlsCert, tlsKey)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS credentials: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	gs := grpc.NewServer(opts...)
+	server.Register(gs)
+
+	lis, err := net.Listen("tcp", listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on [%s]: %w", listen, err)
+	}
+
+	errCh := make(chan error, 2)
+	go func() { errCh <- gs.Serve(lis) }()
+	fmt.Printf("Validation service listening on [%s]\n", lis.Addr())
+
+	var ops *http.Server
+	if len(opsListen) != 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("OK"))
+		})
+		ops = &http.Server{Addr: opsListen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := ops.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- err
+			}
+		}()
+		fmt.Printf("Operations endpoint listening on [%s]\n", opsListen)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	select {
+	case <-sigCh:
+	case err = <-errCh:
+	}
+
+	server.Shutdown()
+	if ops != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = ops.Shutdown(ctx)
+	}
+	gs.GracefulStop()
+
+	return err
+}
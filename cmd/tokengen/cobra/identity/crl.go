@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crypto"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+// crlValidity is the time after which a new list is expected
+const crlValidity = 365 * 24 * time.Hour
+
+// CRLArgs defines the arguments for the generation of a certificate revocation list.
+type CRLArgs struct {
+	// CADir is the directory of the CA generated by GenerateX509CA
+	CADir string
+	// MSPs are the MSP directories whose certificates must be revoked
+	MSPs []string
+	// CRL is the certificate revocation list to extend, a new one is started if empty
+	CRL string
+	// Output is the file where the new certificate revocation list is written
+	Output string
+}
+
+func crlCmd() *cobra.Command {
+	args := &CRLArgs{}
+	c := &cobra.Command{
+		Use:   "crl",
+		Short: "Revoke x509 certificates.",
+		Long: `Revoke the certificates of x509 MSPs signed by a CA generated with x509-ca.
+The output is a PEM encoded certificate revocation list signed by the CA. It is enforced once published in the
+public parameters, together with the CA certificate, with 'tokengen update crl --crls <output> --cas <ca>/ca-cert.pem'.`,
+		RunE: run(func() error {
+			return GenerateCRL(args)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&args.CADir, "ca", "c", "ca", "directory of the CA generated with x509-ca")
+	flags.StringSliceVarP(&args.MSPs, "msps", "m", nil, "MSP folders whose certificates must be revoked")
+	flags.StringVarP(&args.CRL, "crl", "l", "", "certificate revocation list to extend, a new one is started if empty")
+	flags.StringVarP(&args.Output, "output", "o", "crl.pem", "output file")
+
+	return c
+}
+
+// GenerateCRL generates a certificate revocation list signed by the CA as described by args
+func GenerateCRL(args *CRLArgs) error {
+	if len(args.MSPs) == 0 {
+		return errors.New("no certificate to revoke, pass the MSP folders")
+	}
+	ca, caKey, err := loadCA(args.CADir)
+	if err != nil {
+		return err
+	}
+
+	template := &x509.RevocationList{Number: big.NewInt(1)}
+	if len(args.CRL) != 0 {
+		raw, err := os.ReadFile(args.CRL)
+		if err != nil {
+			return errors.Wrapf(err, "failed reading certificate revocation list")
+		}
+		crls, err := crl.ParseCRLs(raw)
+		if err != nil {
+			return err
+		}
+		if len(crls) != 1 {
+			return errors.Errorf("expected a single certificate revocation list in [%s], got [%d]", args.CRL, len(crls))
+		}
+		if err := crls[0].CheckSignatureFrom(ca); err != nil {
+			return errors.Wrapf(err, "certificate revocation list [%s] is not signed by the CA in [%s]", args.CRL, args.CADir)
+		}
+		template.RevokedCertificateEntries = crls[0].RevokedCertificateEntries
+		template.Number = new(big.Int).Add(crls[0].Number, big.NewInt(1))
+	}
+
+	now := time.Now()
+	for _, msp := range args.MSPs {
+		cert, err := readSigncert(msp)
+		if err != nil {
+			return err
+		}
+		if err := cert.CheckSignatureFrom(ca); err != nil {
+			return errors.Wrapf(err, "the certificate in [%s] is not signed by the CA in [%s]", msp, args.CADir)
+		}
+		if revoked(template, cert) {
+			continue
+		}
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   cert.SerialNumber,
+			RevocationTime: now,
+		})
+	}
+	template.ThisUpdate = now
+	template.NextUpdate = now.Add(crlValidity)
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca, caKey)
+	if err != nil {
+		return errors.Wrap(err, "failed creating certificate revocation list")
+	}
+	list, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return errors.Wrap(err, "failed parsing certificate revocation list")
+	}
+
+	return writeFile(args.Output, crl.EncodeCRLs(list))
+}
+
+func revoked(list *x509.RevocationList, cert *x509.Certificate) bool {
+	for _, entry := range list.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readSigncert returns the certificate found in the signcerts folder of the passed MSP
+func readSigncert(msp string) (*x509.Certificate, error) {
+	dir := filepath.Join(msp, signcerts)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading [%s]", dir)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading certificate in [%s]", dir)
+		}
+
+		return crypto.PemDecodeCert(raw)
+	}
+
+	return nil, errors.Errorf("no certificate found in [%s]", dir)
+}
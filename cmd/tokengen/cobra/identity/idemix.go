@@ -0,0 +1,269 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/idemix"
+	dlog "github.com/IBM/idemix/bccsp/schemes/dlog/crypto"
+	"github.com/IBM/idemix/tools/idemixgen/idemixca"
+	math "github.com/IBM/mathlib"
+	crypto2 "github.com/LFDT-Panurus/panurus/token/services/identity/idemix/crypto"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// IdemixCADir is the folder of an idemix CA that contains its secrets
+	IdemixCADir = "ca"
+	// IssuerSecretKeyFile is the name of the idemix issuer secret key file
+	IssuerSecretKeyFile = "IssuerSecretKey"
+	// RevocationKeyFile is the name of the idemix revocation secret key file
+	RevocationKeyFile = "RevocationKey"
+	// CredentialsFile is the name of the file where the CA records the revocation handles issued to each enrollment id
+	CredentialsFile = "Credentials"
+)
+
+// IdemixArgs defines the arguments for the generation of an idemix credential.
+type IdemixArgs struct {
+	// CADir is the directory of the CA generated by GenerateIdemixCA
+	CADir string
+	// Aries selects the aries backend, the CA must have been generated with the same setting
+	Aries bool
+	// OrganizationalUnit is the organizational unit attribute
+	OrganizationalUnit string
+	// EnrollmentID is the enrollment id attribute
+	EnrollmentID string
+	// RevocationHandle is the revocation handle attribute, a random one is chosen if empty
+	RevocationHandle string
+	// Admin sets the admin role attribute instead of the member one
+	Admin bool
+	// Output is the MSP directory to create
+	Output string
+}
+
+func idemixCACmd() *cobra.Command {
+	var output string
+	var aries bool
+	c := &cobra.Command{
+		Use:   "idemix-ca",
+		Short: "Generate an idemix issuer.",
+		Long: `Generate the keys of an idemix credential issuer. The secrets are stored in the ca folder,
+the public keys in the msp folder. The output folder can be passed to 'tokengen gen zkatdlognogh.v1' with --idemix.`,
+		RunE: run(func() error {
+			return GenerateIdemixCA(aries, output)
+		}),
+	}
+	flags := c.Flags()
+	flags.BoolVarP(&aries, "aries", "r", false, "use aries as backend for idemix")
+	flags.StringVarP(&output, "output", "o", "idemix", "output folder")
+
+	return c
+}
+
+func idemixCmd() *cobra.Command {
+	args := &IdemixArgs{}
+	c := &cobra.Command{
+		Use:   "idemix",
+		Short: "Generate an idemix credential.",
+		Long: `Generate an idemix credential for an owner, signed by an issuer generated with idemix-ca.
+The output folder can be referenced by the owner wallets in the token configuration.`,
+		RunE: run(func() error {
+			return GenerateIdemix(args)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&args.CADir, "ca", "c", "idemix", "folder of the issuer generated with idemix-ca")
+	flags.BoolVarP(&args.Aries, "aries", "r", false, "use aries as backend for idemix")
+	flags.StringVarP(&args.OrganizationalUnit, "org-unit", "u", "", "organizational unit attribute")
+	flags.StringVarP(&args.EnrollmentID, "enrollment-id", "e", "", "enrollment id attribute")
+	flags.StringVarP(&args.RevocationHandle, "revocation-handle", "", "", "revocation handle attribute, random if empty")
+	flags.BoolVarP(&args.Admin, "admin", "a", false, "give the admin role instead of the member one")
+	flags.StringVarP(&args.Output, "output", "o", "", "MSP folder to create")
+
+	return c
+}
+
+// GenerateIdemixCA generates the keys of an idemix issuer in output
+func GenerateIdemixCA(aries bool, output string) error {
+	curve, tr, err := idemixCurve(aries)
+	if err != nil {
+		return err
+	}
+	var isk, ipk []byte
+	if aries {
+		isk, ipk, err = idemixca.GenerateIssuerKeyAries(curve)
+	} else {
+		isk, ipk, err = idemixca.GenerateIssuerKey(&dlog.Idemix{Curve: curve}, tr)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed generating issuer key")
+	}
+	revocationKey, err := (&dlog.Idemix{Curve: curve}).GenerateLongTermRevocationKey()
+	if err != nil {
+		return errors.Wrap(err, "failed generating revocation key")
+	}
+	rsk, err := x509.MarshalECPrivateKey(revocationKey)
+	if err != nil {
+		return errors.Wrap(err, "failed marshalling revocation key")
+	}
+	rpk, err := x509.MarshalPKIXPublicKey(revocationKey.Public())
+	if err != nil {
+		return errors.Wrap(err, "failed marshalling revocation public key")
+	}
+
+	for path, raw := range map[string][]byte{
+		filepath.Join(output, IdemixCADir, IssuerSecretKeyFile):                                      isk,
+		filepath.Join(output, IdemixCADir, idemix.IdemixConfigFileIssuerPublicKey):                   ipk,
+		filepath.Join(output, IdemixCADir, RevocationKeyFile):                                        pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rsk}),
+		filepath.Join(output, idemix.IdemixConfigDirMsp, idemix.IdemixConfigFileIssuerPublicKey):     ipk,
+		filepath.Join(output, idemix.IdemixConfigDirMsp, idemix.IdemixConfigFileRevocationPublicKey): pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rpk}),
+	} {
+		if err := writeFile(path, raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenerateIdemix generates an idemix credential as described by args
+func GenerateIdemix(args *IdemixArgs) error {
+	if len(args.Output) == 0 {
+		return errors.New("the output folder is empty")
+	}
+	if len(args.OrganizationalUnit) == 0 || len(args.EnrollmentID) == 0 {
+		return errors.New("the organizational unit and the enrollment id must be set")
+	}
+	curve, tr, err := idemixCurve(args.Aries)
+	if err != nil {
+		return err
+	}
+	isk, err := os.ReadFile(filepath.Join(args.CADir, IdemixCADir, IssuerSecretKeyFile))
+	if err != nil {
+		return errors.Wrapf(err, "failed reading issuer secret key")
+	}
+	ipk, err := os.ReadFile(filepath.Join(args.CADir, idemix.IdemixConfigDirMsp, idemix.IdemixConfigFileIssuerPublicKey))
+	if err != nil {
+		return errors.Wrapf(err, "failed reading issuer public key")
+	}
+	rpk, err := os.ReadFile(filepath.Join(args.CADir, idemix.IdemixConfigDirMsp, idemix.IdemixConfigFileRevocationPublicKey))
+	if err != nil {
+		return errors.Wrapf(err, "failed reading revocation public key")
+	}
+	rsk, err := loadRevocationKey(args.CADir)
+	if err != nil {
+		return err
+	}
+
+	role := idemix.GetRoleMaskFromIdemixRole(idemix.MEMBER)
+	if args.Admin {
+		role = idemix.GetRoleMaskFromIdemixRole(idemix.ADMIN)
+	}
+	rh := args.RevocationHandle
+	if len(rh) == 0 {
+		n, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 63))
+		if err != nil {
+			return errors.Wrap(err, "failed generating revocation handle")
+		}
+		rh = n.String()
+	}
+	var signerConfig []byte
+	if args.Aries {
+		signerConfig, err = idemixca.GenerateSignerConfigAries(role, args.OrganizationalUnit, args.EnrollmentID, rh, isk, ipk, rsk, curve)
+	} else {
+		signerConfig, err = idemixca.GenerateSignerConfig(role, args.OrganizationalUnit, args.EnrollmentID, rh, isk, ipk, rsk, &dlog.Idemix{Curve: curve}, tr)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed generating credential")
+	}
+
+	for path, raw := range map[string][]byte{
+		filepath.Join(args.Output, idemix.IdemixConfigDirUser, idemix.IdemixConfigFileSigner):             signerConfig,
+		filepath.Join(args.Output, idemix.IdemixConfigDirMsp, idemix.IdemixConfigFileIssuerPublicKey):     ipk,
+		filepath.Join(args.Output, idemix.IdemixConfigDirMsp, idemix.IdemixConfigFileRevocationPublicKey): rpk,
+	} {
+		if err := writeFile(path, raw); err != nil {
+			return err
+		}
+	}
+
+	return recordCredential(args.CADir, args.EnrollmentID, rh)
+}
+
+// idemixCurve returns the curve used by 'tokengen gen zkatdlognogh.v1' for the passed backend
+func idemixCurve(aries bool) (*math.Curve, dlog.Translator, error) {
+	curveID := math.BN254
+	if aries {
+		curveID = math.BLS12_381_BBS_GURVY
+	}
+	curve, tr, _, err := crypto2.GetCurveAndTranslator(curveID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return curve, tr, nil
+}
+
+func loadRevocationKey(caDir string) (*ecdsa.PrivateKey, error) {
+	raw, err := os.ReadFile(filepath.Join(caDir, IdemixCADir, RevocationKeyFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading revocation key")
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.Errorf("no pem content in revocation key in [%s]", caDir)
+	}
+	rsk, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed parsing revocation key")
+	}
+
+	return rsk, nil
+}
+
+// loadCredentials returns the revocation handles issued by the CA to each enrollment id
+func loadCredentials(caDir string) (map[string][]string, error) {
+	credentials := map[string][]string{}
+	raw, err := os.ReadFile(filepath.Join(caDir, IdemixCADir, CredentialsFile))
+	if os.IsNotExist(err) {
+		return credentials, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading issued credentials")
+	}
+	if err := json.Unmarshal(raw, &credentials); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshalling issued credentials")
+	}
+
+	return credentials, nil
+}
+
+func recordCredential(caDir, enrollmentID, rh string) error {
+	credentials, err := loadCredentials(caDir)
+	if err != nil {
+		return err
+	}
+	credentials[enrollmentID] = append(credentials[enrollmentID], rh)
+	raw, err := json.MarshalIndent(credentials, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed marshalling issued credentials")
+	}
+	if err := os.WriteFile(filepath.Join(caDir, IdemixCADir, CredentialsFile), raw, 0o600); err != nil {
+		return errors.Wrapf(err, "failed recording issued credential")
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+// Cmd returns the Cobra Command for generating identity material.
+func Cmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "identity",
+		Short: "Manage identity material.",
+		Long:  `Manage identity material.`,
+	}
+	gen := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate identity material.",
+		Long: `Generate identity material for issuers, auditors and owners.
+The x509 and idemix MSP directories can be passed to 'tokengen gen' and referenced by the wallets
+in the token configuration. Multisig and policy identities are written as serialized identities.`,
+	}
+	gen.AddCommand(x509CACmd(), x509Cmd(), idemixCACmd(), idemixCmd(), multisigCmd(), policyCmd())
+	c.AddCommand(gen, revokeCmd(), crlCmd())
+
+	return c
+}
+
+func run(f func() error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return errors.New("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		return f()
+	}
+}
+
+// writeFile writes raw to path, creating the parent directories. It refuses to overwrite existing files.
+func writeFile(path string, raw []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		return errors.Errorf("%s already exists, specify another output", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return errors.Wrapf(err, "failed creating directory of [%s]", path)
+	}
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		return errors.Wrapf(err, "failed writing [%s]", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/pp/common"
+	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/setup"
+	"github.com/LFDT-Panurus/panurus/token/services/identity"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/boolpolicy"
+	crypto2 "github.com/LFDT-Panurus/panurus/token/services/identity/idemix/crypto"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix/revocation"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/multisig"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/sigscheme"
+	x5092 "github.com/LFDT-Panurus/panurus/token/services/identity/x509"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
+	"github.com/LFDT-Panurus/panurus/token/services/storage/db/kvs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestX509(t *testing.T) {
+	dir := t.TempDir()
+	ca := filepath.Join(dir, "ca")
+	require.NoError(t, GenerateX509CA("ca", KeyTypeECDSA, ca))
+
+	// an ECDSA MSP signed by the CA
+	alice := filepath.Join(dir, "alice")
+	require.NoError(t, GenerateX509(&X509Args{Name: "alice", OrganizationalUnit: "org1", KeyType: KeyTypeECDSA, CADir: ca, Output: alice}))
+	require.ErrorContains(t, GenerateX509(&X509Args{Name: "alice", KeyType: KeyTypeECDSA, CADir: ca, Output: alice}), "already exists")
+	km, _, err := x5092.NewKeyManager(alice, nil, x5092.NewKeyStore(kvs.NewTrackedMemory()))
+	require.NoError(t, err)
+	require.NotNil(t, km.SigningIdentity())
+	cert := readCertificate(t, filepath.Join(alice, signcerts, "alice-cert.pem"))
+	roots := x509.NewCertPool()
+	roots.AddCert(readCertificate(t, filepath.Join(alice, cacerts, CACertFile)))
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org1"}, cert.Subject.OrganizationalUnit)
+
+	// a self-signed Ed25519 MSP
+	bob := filepath.Join(dir, "bob")
+	require.NoError(t, GenerateX509(&X509Args{Name: "bob", KeyType: KeyTypeEd25519, Output: bob}))
+	id, err := common.GetX509Identity(bob)
+	require.NoError(t, err)
+	typed, err := identity.UnmarshalTypedIdentity(id)
+	require.NoError(t, err)
+	assert.Equal(t, sigscheme.Ed25519IdentityType, typed.Type)
+	ed25519km, err := sigscheme.LoadKeyManager(bob)
+	require.NoError(t, err)
+	descriptor, err := ed25519km.Identity(context.Background(), nil)
+	require.NoError(t, err)
+	assert.NotNil(t, descriptor.Signer)
+
+	require.Error(t, GenerateX509(&X509Args{Name: "charlie", KeyType: "rsa", Output: filepath.Join(dir, "charlie")}))
+
+	// composed identities
+	require.NoError(t, Multisig([]string{alice, bob}, filepath.Join(dir, "multisig.id")))
+	raw, err := os.ReadFile(filepath.Join(dir, "multisig.id"))
+	require.NoError(t, err)
+	ids, ok, err := multisig.Unwrap(raw)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, ids, 2)
+
+	require.Error(t, Policy("$0 OR", []string{alice, bob}, filepath.Join(dir, "policy.id")))
+	require.NoError(t, Policy("$0 OR $1", []string{alice, bob}, filepath.Join(dir, "policy.id")))
+	raw, err = os.ReadFile(filepath.Join(dir, "policy.id"))
+	require.NoError(t, err)
+	pi, ok, err := boolpolicy.Unwrap(raw)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "$0 OR $1", pi.Policy)
+}
+
+func TestIdemix(t *testing.T) {
+	for _, aries := range []bool{false, true} {
+		dir := t.TempDir()
+		ca := filepath.Join(dir, "idemix")
+		require.NoError(t, GenerateIdemixCA(aries, ca))
+
+		owner := filepath.Join(dir, "alice")
+		require.Error(t, GenerateIdemix(&IdemixArgs{CADir: ca, Aries: aries, OrganizationalUnit: "org1", Output: owner}))
+		require.NoError(t, GenerateIdemix(&IdemixArgs{CADir: ca, Aries: aries, OrganizationalUnit: "org1", EnrollmentID: "alice", RevocationHandle: "150", Output: owner}))
+		config, err := crypto2.NewConfig(owner)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", config.Signer.EnrollmentId)
+		assert.Equal(t, "150", config.Signer.RevocationHandle)
+
+		// the issuer public key can be used to set up the public parameters
+		curveID := math.BN254
+		if aries {
+			curveID = math.BLS12_381_BBS_GURVY
+		}
+		_, err = setup.Setup(32, config.Ipk, curveID)
+		require.NoError(t, err)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	dir := t.TempDir()
+	ca := filepath.Join(dir, "idemix")
+	require.NoError(t, GenerateIdemixCA(false, ca))
+	require.NoError(t, GenerateIdemix(&IdemixArgs{CADir: ca, OrganizationalUnit: "org1", EnrollmentID: "alice", RevocationHandle: "150", Output: filepath.Join(dir, "alice")}))
+	require.NoError(t, GenerateIdemix(&IdemixArgs{CADir: ca, OrganizationalUnit: "org1", EnrollmentID: "bob", Output: filepath.Join(dir, "bob")}))
+
+	require.ErrorContains(t, Revoke(&RevokeArgs{CADir: ca, Output: filepath.Join(dir, "empty.json")}), "no credential to revoke")
+	require.ErrorContains(t, Revoke(&RevokeArgs{CADir: ca, EnrollmentIDs: []string{"charlie"}, Output: filepath.Join(dir, "charlie.json")}), "no credential issued to [charlie]")
+
+	first := filepath.Join(dir, "revocation1.json")
+	require.NoError(t, Revoke(&RevokeArgs{CADir: ca, EnrollmentIDs: []string{"alice"}, Output: first}))
+	rsk, err := loadRevocationKey(ca)
+	require.NoError(t, err)
+	rpk := &rsk.PublicKey
+	list := readRevocationList(t, first)
+	assert.Equal(t, uint64(1), list.Epoch)
+	assert.Equal(t, []string{"150"}, list.RevocationHandles)
+	require.NoError(t, list.VerifyWith(rpk))
+
+	// extend the previous list
+	second := filepath.Join(dir, "revocation2.json")
+	require.NoError(t, Revoke(&RevokeArgs{CADir: ca, EnrollmentIDs: []string{"bob"}, RevocationHandles: []string{"42"}, List: first, Output: second}))
+	list = readRevocationList(t, second)
+	assert.Equal(t, uint64(2), list.Epoch)
+	assert.Len(t, list.RevocationHandles, 3)
+	assert.True(t, list.IsRevoked("150"))
+	assert.True(t, list.IsRevoked("42"))
+	require.NoError(t, list.VerifyWith(rpk))
+
+	// lists of other CAs are rejected
+	other := filepath.Join(dir, "other")
+	require.NoError(t, GenerateIdemixCA(false, other))
+	require.ErrorContains(t, Revoke(&RevokeArgs{CADir: other, RevocationHandles: []string{"1"}, List: second, Output: filepath.Join(dir, "revocation3.json")}), "does not refer to the issuer")
+}
+
+func TestCRL(t *testing.T) {
+	dir := t.TempDir()
+	ca := filepath.Join(dir, "ca")
+	require.NoError(t, GenerateX509CA("ca", KeyTypeEd25519, ca))
+	alice := filepath.Join(dir, "alice")
+	require.NoError(t, GenerateX509(&X509Args{Name: "alice", KeyType: KeyTypeECDSA, CADir: ca, Output: alice}))
+	bob := filepath.Join(dir, "bob")
+	require.NoError(t, GenerateX509(&X509Args{Name: "bob", KeyType: KeyTypeECDSA, CADir: ca, Output: bob}))
+	charlie := filepath.Join(dir, "charlie")
+	require.NoError(t, GenerateX509(&X509Args{Name: "charlie", KeyType: KeyTypeECDSA, Output: charlie}))
+
+	require.ErrorContains(t, GenerateCRL(&CRLArgs{CADir: ca, Output: filepath.Join(dir, "empty.pem")}), "no certificate to revoke")
+	require.ErrorContains(t, GenerateCRL(&CRLArgs{CADir: ca, MSPs: []string{charlie}, Output: filepath.Join(dir, "charlie.pem")}), "is not signed by the CA")
+
+	first := filepath.Join(dir, "crl1.pem")
+	require.NoError(t, GenerateCRL(&CRLArgs{CADir: ca, MSPs: []string{alice}, Output: first}))
+	second := filepath.Join(dir, "crl2.pem")
+	require.NoError(t, GenerateCRL(&CRLArgs{CADir: ca, MSPs: []string{alice, bob}, CRL: first, Output: second}))
+	raw, err := os.ReadFile(second)
+	require.NoError(t, err)
+	crls, err := crl.ParseCRLs(raw)
+	require.NoError(t, err)
+	require.Len(t, crls, 1)
+	assert.Equal(t, int64(2), crls[0].Number.Int64())
+	assert.Len(t, crls[0].RevokedCertificateEntries, 2)
+
+	checker := crl.NewChecker(crl.StaticSource(crls), []*x509.Certificate{readCertificate(t, filepath.Join(ca, CACertFile))}, crl.Policy{})
+	require.ErrorContains(t, checker.Check(t.Context(), readCertificate(t, filepath.Join(alice, signcerts, "alice-cert.pem"))), "has been revoked")
+	require.ErrorContains(t, checker.Check(t.Context(), readCertificate(t, filepath.Join(bob, signcerts, "bob-cert.pem"))), "has been revoked")
+	require.NoError(t, checker.Check(t.Context(), readCertificate(t, filepath.Join(charlie, signcerts, "charlie-cert.pem"))))
+}
+
+func TestCmd(t *testing.T) {
+	cmd := Cmd()
+	require.Len(t, cmd.Commands(), 3)
+	gen, _, err := cmd.Find([]string{"gen"})
+	require.NoError(t, err)
+	assert.Equal(t, "gen", gen.Name())
+	assert.Len(t, gen.Commands(), 6)
+	cmd.SetArgs([]string{"gen", "x509", "--name", "alice", "extra"})
+	require.ErrorContains(t, cmd.Execute(), "trailing args detected")
+}
+
+func readRevocationList(t *testing.T, path string) *revocation.List {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	list := &revocation.List{}
+	require.NoError(t, list.FromBytes(raw))
+
+	return list
+}
+
+func readCertificate(t *testing.T, path string) *x509.Certificate {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	return cert
+}
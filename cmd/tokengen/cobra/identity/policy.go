@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/pp/common"
+	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/boolpolicy"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/multisig"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+func multisigCmd() *cobra.Command {
+	var msps []string
+	var output string
+	c := &cobra.Command{
+		Use:   "multisig",
+		Short: "Generate a multisig identity.",
+		Long:  "Generate a multisig identity owned jointly by the passed x509 MSPs, all of them must sign to spend.",
+		RunE: run(func() error {
+			return Multisig(msps, output)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringSliceVarP(&msps, "msps", "m", nil, "list of x509 MSP directories of the co-owners")
+	flags.StringVarP(&output, "output", "o", "multisig.id", "output file")
+
+	return c
+}
+
+func policyCmd() *cobra.Command {
+	var msps []string
+	var policy, output string
+	c := &cobra.Command{
+		Use:   "policy",
+		Short: "Generate a policy identity.",
+		Long: `Generate a policy identity owned by the passed x509 MSPs under a boolean policy,
+for instance "$0 OR ($1 AND $2)", where $i refers to the i-th MSP.`,
+		RunE: run(func() error {
+			return Policy(policy, msps, output)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&policy, "policy", "p", "", "boolean policy over the MSPs")
+	flags.StringSliceVarP(&msps, "msps", "m", nil, "list of x509 MSP directories referenced by the policy")
+	flags.StringVarP(&output, "output", "o", "policy.id", "output file")
+
+	return c
+}
+
+// Multisig writes to output the multisig identity of the passed x509 MSPs
+func Multisig(msps []string, output string) error {
+	ids, err := loadIdentities(msps)
+	if err != nil {
+		return err
+	}
+	id, err := multisig.WrapIdentities(ids...)
+	if err != nil {
+		return errors.WithMessagef(err, "failed creating multisig identity")
+	}
+
+	return writeFile(output, id)
+}
+
+// Policy writes to output the policy identity of the passed x509 MSPs under the passed policy
+func Policy(policy string, msps []string, output string) error {
+	if _, err := boolpolicy.Parse(policy); err != nil {
+		return errors.WithMessagef(err, "invalid policy [%s]", policy)
+	}
+	ids, err := loadIdentities(msps)
+	if err != nil {
+		return err
+	}
+	id, err := boolpolicy.WrapPolicyIdentity(policy, ids...)
+	if err != nil {
+		return errors.WithMessagef(err, "failed creating policy identity")
+	}
+
+	return writeFile(output, id)
+}
+
+func loadIdentities(msps []string) ([]driver.Identity, error) {
+	if len(msps) == 0 {
+		return nil, errors.New("no MSP passed")
+	}
+	ids := make([]driver.Identity, 0, len(msps))
+	for _, msp := range msps {
+		id, err := common.GetX509Identity(msp)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get identity [%s]", msp)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
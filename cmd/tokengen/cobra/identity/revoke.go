@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/idemix"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix/revocation"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+// RevokeArgs defines the arguments for the revocation of idemix credentials.
+type RevokeArgs struct {
+	// CADir is the directory of the CA generated by GenerateIdemixCA
+	CADir string
+	// EnrollmentIDs are the enrollment ids whose credentials must be revoked
+	EnrollmentIDs []string
+	// RevocationHandles are additional revocation handles to revoke
+	RevocationHandles []string
+	// List is the revocation list to extend, a new one is started if empty
+	List string
+	// Output is the file where the new revocation list is written
+	Output string
+}
+
+func revokeCmd() *cobra.Command {
+	args := &RevokeArgs{}
+	c := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke idemix credentials.",
+		Long: `Revoke the idemix credentials issued by a CA generated with idemix-ca.
+The output is a revocation list signed with the revocation key of the CA. It is enforced once published in the
+public parameters with 'tokengen update zkatdlognogh.v1 --extra idemix.revocation=<output>', provided that the
+public parameters pin the revocation public key of the CA, as 'tokengen gen zkatdlognogh.v1' does.`,
+		RunE: run(func() error {
+			return Revoke(args)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&args.CADir, "ca", "c", "idemix", "folder of the issuer generated with idemix-ca")
+	flags.StringSliceVarP(&args.EnrollmentIDs, "enrollment-ids", "e", nil, "enrollment ids whose credentials must be revoked")
+	flags.StringSliceVarP(&args.RevocationHandles, "revocation-handles", "r", nil, "revocation handles to revoke")
+	flags.StringVarP(&args.List, "list", "l", "", "revocation list to extend, a new one is started if empty")
+	flags.StringVarP(&args.Output, "output", "o", "revocation.json", "output file")
+
+	return c
+}
+
+// Revoke adds the credentials described by args to a revocation list signed by the CA
+func Revoke(args *RevokeArgs) error {
+	ipk, err := os.ReadFile(filepath.Join(args.CADir, idemix.IdemixConfigDirMsp, idemix.IdemixConfigFileIssuerPublicKey))
+	if err != nil {
+		return errors.Wrapf(err, "failed reading issuer public key")
+	}
+	rsk, err := loadRevocationKey(args.CADir)
+	if err != nil {
+		return err
+	}
+
+	list := revocation.NewList(ipk)
+	if len(args.List) != 0 {
+		raw, err := os.ReadFile(args.List)
+		if err != nil {
+			return errors.Wrapf(err, "failed reading revocation list")
+		}
+		list = &revocation.List{}
+		if err := list.FromBytes(raw); err != nil {
+			return errors.Wrapf(err, "failed unmarshalling revocation list")
+		}
+		if !list.Refers(ipk) {
+			return errors.Errorf("revocation list [%s] does not refer to the issuer in [%s]", args.List, args.CADir)
+		}
+		if err := list.VerifyWith(&rsk.PublicKey); err != nil {
+			return errors.WithMessagef(err, "revocation list [%s] is not signed by the CA", args.List)
+		}
+	}
+
+	handles := append([]string{}, args.RevocationHandles...)
+	if len(args.EnrollmentIDs) != 0 {
+		credentials, err := loadCredentials(args.CADir)
+		if err != nil {
+			return err
+		}
+		for _, eid := range args.EnrollmentIDs {
+			rhs, ok := credentials[eid]
+			if !ok {
+				return errors.Errorf("no credential issued to [%s]", eid)
+			}
+			handles = append(handles, rhs...)
+		}
+	}
+	if len(handles) == 0 {
+		return errors.New("no credential to revoke, pass enrollment ids or revocation handles")
+	}
+
+	list.Revoke(handles...)
+	if err := list.Sign(rsk); err != nil {
+		return err
+	}
+	raw, err := list.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return writeFile(args.Output, raw)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// KeyTypeECDSA generates ECDSA P-256 keys
+	KeyTypeECDSA = "ecdsa"
+	// KeyTypeEd25519 generates Ed25519 keys
+	KeyTypeEd25519 = "ed25519"
+
+	// CACertFile is the name of the certificate file of an x509 CA
+	CACertFile = "ca-cert.pem"
+	// PrivateKeyFile is the name of the private key file, as expected by the x509 key manager
+	PrivateKeyFile = "priv_sk"
+
+	signcerts = "signcerts"
+	keystore  = "keystore"
+	cacerts   = "cacerts"
+
+	validity = 10 * 365 * 24 * time.Hour
+)
+
+// X509Args defines the arguments for the generation of an x509 MSP.
+type X509Args struct {
+	// Name is the common name of the certificate
+	Name string
+	// OrganizationalUnit is the organizational unit of the certificate, optional
+	OrganizationalUnit string
+	// KeyType is the type of the key, ecdsa or ed25519
+	KeyType string
+	// CADir is the directory of the CA generated by GenerateX509CA. If empty, the certificate is self-signed.
+	CADir string
+	// Output is the MSP directory to create
+	Output string
+}
+
+func x509CACmd() *cobra.Command {
+	var name, keyType, output string
+	c := &cobra.Command{
+		Use:   "x509-ca",
+		Short: "Generate an x509 CA.",
+		Long:  "Generate an x509 CA, with its certificate in " + CACertFile + " and its key in " + PrivateKeyFile + ", to sign the certificates of x509 MSPs.",
+		RunE: run(func() error {
+			return GenerateX509CA(name, keyType, output)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&name, "name", "n", "ca", "common name of the CA")
+	flags.StringVarP(&keyType, "key-type", "k", KeyTypeECDSA, "key type: ecdsa or ed25519")
+	flags.StringVarP(&output, "output", "o", "ca", "output folder")
+
+	return c
+}
+
+func x509Cmd() *cobra.Command {
+	args := &X509Args{}
+	c := &cobra.Command{
+		Use:   "x509",
+		Short: "Generate an x509 MSP.",
+		Long: `Generate an x509 MSP for an issuer, an auditor or an owner, with the certificate in signcerts,
+the key in keystore and the CA certificate in cacerts. The MSP directory can be passed to
+'tokengen gen' with --issuers and --auditors, or referenced by the wallets in the token configuration.`,
+		RunE: run(func() error {
+			return GenerateX509(args)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&args.Name, "name", "n", "", "common name of the certificate")
+	flags.StringVarP(&args.OrganizationalUnit, "org-unit", "u", "", "organizational unit of the certificate")
+	flags.StringVarP(&args.KeyType, "key-type", "k", KeyTypeECDSA, "key type: ecdsa or ed25519")
+	flags.StringVarP(&args.CADir, "ca", "c", "", "directory of the CA generated with x509-ca, the certificate is self-signed if empty")
+	flags.StringVarP(&args.Output, "output", "o", "", "MSP folder to create")
+
+	return c
+}
+
+// GenerateX509CA generates a CA key and self-signed certificate in output
+func GenerateX509CA(name, keyType, output string) error {
+	sk, err := newKey(keyType)
+	if err != nil {
+		return err
+	}
+	template := certificateTemplate(name, "")
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, template, template, sk.Public(), sk)
+	if err != nil {
+		return errors.Wrap(err, "failed creating CA certificate")
+	}
+	if err := writeFile(filepath.Join(output, CACertFile), encodeCertificate(der)); err != nil {
+		return err
+	}
+
+	return writeKey(filepath.Join(output, PrivateKeyFile), sk)
+}
+
+// GenerateX509 generates an x509 MSP as described by args
+func GenerateX509(args *X509Args) error {
+	if len(args.Name) == 0 {
+		return errors.New("the common name is empty")
+	}
+	if len(args.Output) == 0 {
+		return errors.New("the output folder is empty")
+	}
+	sk, err := newKey(args.KeyType)
+	if err != nil {
+		return err
+	}
+	template := certificateTemplate(args.Name, args.OrganizationalUnit)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+
+	parent, parentKey := template, crypto.Signer(sk)
+	if len(args.CADir) != 0 {
+		parent, parentKey, err = loadCA(args.CADir)
+		if err != nil {
+			return err
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, sk.Public(), parentKey)
+	if err != nil {
+		return errors.Wrap(err, "failed creating certificate")
+	}
+
+	if err := writeFile(filepath.Join(args.Output, signcerts, args.Name+"-cert.pem"), encodeCertificate(der)); err != nil {
+		return err
+	}
+	if err := writeKey(filepath.Join(args.Output, keystore, PrivateKeyFile), sk); err != nil {
+		return err
+	}
+	caCert := encodeCertificate(der)
+	if len(args.CADir) != 0 {
+		caCert = encodeCertificate(parent.Raw)
+	}
+
+	return writeFile(filepath.Join(args.Output, cacerts, CACertFile), caCert)
+}
+
+func newKey(keyType string) (crypto.Signer, error) {
+	switch keyType {
+	case KeyTypeECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyTypeEd25519:
+		_, sk, err := ed25519.GenerateKey(rand.Reader)
+
+		return sk, err
+	default:
+		return nil, errors.Errorf("invalid key type [%s], expected ecdsa or ed25519", keyType)
+	}
+}
+
+func certificateTemplate(name, ou string) *x509.Certificate {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		serial = big.NewInt(time.Now().UnixNano())
+	}
+	subject := pkix.Name{CommonName: name}
+	if len(ou) != 0 {
+		subject.OrganizationalUnit = []string{ou}
+	}
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    now.Add(-5 * time.Minute),
+		NotAfter:     now.Add(validity),
+	}
+}
+
+func encodeCertificate(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func writeKey(path string, sk crypto.Signer) error {
+	raw, err := x509.MarshalPKCS8PrivateKey(sk)
+	if err != nil {
+		return errors.Wrap(err, "failed marshalling private key")
+	}
+
+	return writeFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: raw}))
+}
+
+func loadCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	certRaw, err := os.ReadFile(filepath.Join(dir, CACertFile))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading CA certificate")
+	}
+	block, _ := pem.Decode(certRaw)
+	if block == nil {
+		return nil, nil, errors.Errorf("no pem content in CA certificate in [%s]", dir)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed parsing CA certificate")
+	}
+	keyRaw, err := os.ReadFile(filepath.Join(dir, PrivateKeyFile))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading CA key")
+	}
+	block, _ = pem.Decode(keyRaw)
+	if block == nil {
+		return nil, nil, errors.Errorf("no pem content in CA key in [%s]", dir)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed parsing CA key")
+	}
+	sk, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.Errorf("unsupported CA key type [%T]", key)
+	}
+
+	return cert, sk, nil
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/LFDT-Panurus/panurus/token/driver"
 	"github.com/LFDT-Panurus/panurus/token/services/identity"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/sigscheme"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/x509"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 )
@@ -33,6 +34,7 @@ type PP interface {
 
 // GetX509Identity returns the x509 identity from the passed entry directory.
 // It expects to find certificates in a 'signcerts' subdirectory within the entry directory.
+// Certificates with an Ed25519 or ML-DSA key are wrapped with the corresponding identity type.
 func GetX509Identity(entry string) (driver.Identity, error) {
 	// read certificate from entries[0]/signcerts
 	signcertDir := filepath.Join(entry, signcerts)
@@ -44,7 +46,11 @@ func GetX509Identity(entry string) (driver.Identity, error) {
 		return nil, errors.Errorf("no certificates found in %s", signcertDir)
 	}
 
-	wrap, err := identity.WrapWithType(x509.IdentityType, content[0])
+	identityType := x509.IdentityType
+	if t, err := sigscheme.IdentityTypeOfCertificate(content[0]); err == nil {
+		identityType = t
+	}
+	wrap, err := identity.WrapWithType(identityType, content[0])
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to wrap x509 identity for [%s]", entry)
 	}
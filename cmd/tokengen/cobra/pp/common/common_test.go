@@ -8,6 +8,7 @@ package common
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -20,6 +21,7 @@ import (
 	"time"
 
 	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -387,6 +389,23 @@ func TestGetX509Identity(t *testing.T) {
 		assert.NotNil(t, id)
 	})
 
+	t.Run("ed25519", func(t *testing.T) {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "issuer"}}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+		require.NoError(t, err)
+		dir := filepath.Join(tempDir, "ed25519")
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "signcerts"), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "signcerts", "cert.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+
+		id, err := GetX509Identity(dir)
+		require.NoError(t, err)
+		typed, err := identity.UnmarshalTypedIdentity(id)
+		require.NoError(t, err)
+		assert.Equal(t, driver.Ed25519IdentityType, typed.Type)
+	})
+
 	t.Run("no_signcerts", func(t *testing.T) {
 		dir := filepath.Join(tempDir, "no_signcerts")
 		err := os.MkdirAll(dir, 0750)
@@ -0,0 +1,155 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crl
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/LFDT-Panurus/panurus/token/core"
+	fabtoken "github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/driver"
+	dlog "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+// UpdateArgs defines the arguments for updating the certificate revocation lists of public parameters.
+type UpdateArgs struct {
+	// InputFile is the file that contains the public parameters
+	InputFile string
+	// CRLs are the PEM files of the certificate revocation lists that replace the current ones.
+	// If empty, the current lists are kept.
+	CRLs []string
+	// CAs are the PEM files of the certificates of the authorities that sign the lists, they replace the current ones.
+	// If empty, the current certificates are kept.
+	CAs []string
+	// Policy replaces the current certificate validity policy, if not nil
+	Policy *crl.Policy
+	// OutputDir is the directory where the updated public parameters are written, with the name of the input file
+	OutputDir string
+}
+
+// Cmd returns the Cobra Command for updating the certificate revocation lists of public parameters.
+func Cmd() *cobra.Command {
+	args := &UpdateArgs{}
+	var (
+		checkExpiry bool
+		clockSkew   time.Duration
+	)
+	c := &cobra.Command{
+		Use:   "crl",
+		Short: "Update the certificate revocation lists in the public parameters file.",
+		Long: `Replace the certificate revocation lists, the certificates of the CAs that sign them and the certificate
+validity policy of fabtoken and zkatdlog public parameters. Validators reject x509, Ed25519, ML-DSA and hybrid
+identities whose certificate is listed in one of the lists or, if the policy requires it, is outside its validity
+period extended by the clock skew. Each list must be signed by one of the CAs.`,
+		RunE: func(cmd *cobra.Command, a []string) error {
+			if len(a) != 0 {
+				return errors.New("trailing args detected")
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+			if cmd.Flags().Changed("check-expiry") || cmd.Flags().Changed("clock-skew") {
+				args.Policy = &crl.Policy{CheckExpiry: checkExpiry, ClockSkew: clockSkew}
+			}
+
+			return Update(args)
+		},
+	}
+	flags := c.Flags()
+	flags.StringVarP(&args.InputFile, "input", "i", "", "path of the public param file")
+	flags.StringSliceVarP(&args.CRLs, "crls", "c", nil, "PEM files of the certificate revocation lists replacing the current ones")
+	flags.StringSliceVarP(&args.CAs, "cas", "a", nil, "PEM files of the certificates of the CAs signing the lists, replacing the current ones")
+	flags.BoolVarP(&checkExpiry, "check-expiry", "e", false, "reject certificates outside their validity period")
+	flags.DurationVarP(&clockSkew, "clock-skew", "k", 0, "tolerance applied to the validity period of certificates")
+	flags.StringVarP(&args.OutputDir, "output", "o", ".", "output folder")
+
+	return c
+}
+
+// Update replaces the certificate revocation lists and the validity policy of the public parameters as described by args
+func Update(args *UpdateArgs) error {
+	if len(args.CRLs) == 0 && len(args.CAs) == 0 && args.Policy == nil {
+		return errors.New("nothing to update, pass certificate revocation lists, CA certificates or a validity policy")
+	}
+	raw, err := os.ReadFile(args.InputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read public parameters at [%s]", args.InputFile)
+	}
+	pp, err := core.NewPPManagerFactoryService(fabtoken.NewPPMFactory(), dlog.NewPPMFactory()).PublicParametersFromBytes(raw)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to unmarshal public parameters at [%s]", args.InputFile)
+	}
+	extras := pp.Extras()
+	if extras == nil {
+		return errors.Errorf("public parameters at [%s] do not support extras", args.InputFile)
+	}
+
+	if len(args.CRLs) != 0 {
+		var crls []*x509.RevocationList
+		for _, path := range args.CRLs {
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read certificate revocation list at [%s]", path)
+			}
+			lists, err := crl.ParseCRLs(raw)
+			if err != nil {
+				return errors.WithMessagef(err, "invalid certificate revocation list at [%s]", path)
+			}
+			crls = append(crls, lists...)
+		}
+		extras[crl.CRLsExtrasKey] = crl.EncodeCRLs(crls...)
+	}
+	if len(args.CAs) != 0 {
+		var cas []*x509.Certificate
+		for _, path := range args.CAs {
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read CA certificate at [%s]", path)
+			}
+			certs, err := crl.ParseCAs(raw)
+			if err != nil {
+				return errors.WithMessagef(err, "invalid CA certificate at [%s]", path)
+			}
+			cas = append(cas, certs...)
+		}
+		extras[crl.CAsExtrasKey] = crl.EncodeCAs(cas...)
+	}
+	if args.Policy != nil {
+		if args.Policy.ClockSkew < 0 {
+			return errors.Errorf("invalid negative clock skew [%s]", args.Policy.ClockSkew)
+		}
+		raw, err := args.Policy.Bytes()
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal certificate validity policy")
+		}
+		extras[crl.PolicyExtrasKey] = raw
+	}
+	if _, err := crl.NewCheckerFromExtras(extras); err != nil {
+		return errors.WithMessagef(err, "invalid certificate revocation settings")
+	}
+	if err := pp.Validate(); err != nil {
+		return errors.Wrapf(err, "failed to validate public parameters")
+	}
+
+	raw, err = pp.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed serializing public parameters")
+	}
+	path := filepath.Join(args.OutputDir, filepath.Base(args.InputFile))
+	if _, err := os.Stat(path); err == nil {
+		return errors.Errorf("%s already exists, specify another output folder with -o", path)
+	}
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		return errors.Wrap(err, "failed writing public parameters to file")
+	}
+
+	return nil
+}
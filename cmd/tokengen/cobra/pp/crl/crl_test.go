@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crl
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LFDT-Panurus/panurus/token/core"
+	fabtoken "github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/driver"
+	dlog "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCmd tests the Cmd function.
+func TestCmd(t *testing.T) {
+	cmd := Cmd()
+	assert.Equal(t, "crl", cmd.Use)
+	cmd.SetArgs([]string{"extra"})
+	require.ErrorContains(t, cmd.Execute(), "trailing args detected")
+}
+
+// TestUpdate tests the Update function.
+func TestUpdate(t *testing.T) {
+	wd, _ := os.Getwd()
+	input := filepath.Join(wd, "..", "..", "..", "testdata", "zkatdlognoghv1_pp.json")
+	dir := t.TempDir()
+	crlFile := filepath.Join(dir, "crl.pem")
+	caFile := filepath.Join(dir, "ca.pem")
+	rawCRL, rawCA := newCRL(t)
+	require.NoError(t, os.WriteFile(crlFile, rawCRL, 0o600))
+	require.NoError(t, os.WriteFile(caFile, rawCA, 0o600))
+
+	require.ErrorContains(t, Update(&UpdateArgs{InputFile: input, OutputDir: dir}), "nothing to update")
+	require.ErrorContains(t, Update(&UpdateArgs{InputFile: input, CRLs: []string{input}, OutputDir: dir}), "invalid certificate revocation list")
+	require.ErrorContains(t, Update(&UpdateArgs{InputFile: input, Policy: &crl.Policy{ClockSkew: -time.Second}, OutputDir: dir}), "invalid negative clock skew")
+	require.ErrorContains(t, Update(&UpdateArgs{InputFile: input, CAs: []string{crlFile}, OutputDir: dir}), "invalid CA certificate")
+	// the lists must be signed by a published CA
+	require.ErrorContains(t, Update(&UpdateArgs{InputFile: input, CRLs: []string{crlFile}, OutputDir: dir}), "is not signed by a trusted CA")
+
+	// set the lists and the policy
+	first := filepath.Join(dir, "first")
+	require.NoError(t, os.MkdirAll(first, 0o750))
+	policy := &crl.Policy{CheckExpiry: true, ClockSkew: time.Minute}
+	require.NoError(t, Update(&UpdateArgs{InputFile: input, CRLs: []string{crlFile, crlFile}, CAs: []string{caFile}, Policy: policy, OutputDir: first}))
+	checker := readChecker(t, filepath.Join(first, "zkatdlognoghv1_pp.json"))
+	assert.Equal(t, *policy, checker.Policy)
+	assert.Len(t, checker.CAs, 1)
+	crls, err := checker.Source.CRLs()
+	require.NoError(t, err)
+	assert.Len(t, crls, 2)
+	require.ErrorContains(t, Update(&UpdateArgs{InputFile: input, CRLs: []string{crlFile}, CAs: []string{caFile}, OutputDir: first}), "already exists")
+
+	// rotate the lists, the CAs and the policy are kept
+	second := filepath.Join(dir, "second")
+	require.NoError(t, os.MkdirAll(second, 0o750))
+	require.NoError(t, Update(&UpdateArgs{InputFile: filepath.Join(first, "zkatdlognoghv1_pp.json"), CRLs: []string{crlFile}, OutputDir: second}))
+	checker = readChecker(t, filepath.Join(second, "zkatdlognoghv1_pp.json"))
+	assert.Equal(t, *policy, checker.Policy)
+	assert.Len(t, checker.CAs, 1)
+	crls, err = checker.Source.CRLs()
+	require.NoError(t, err)
+	assert.Len(t, crls, 1)
+}
+
+func readChecker(t *testing.T, path string) *crl.Checker {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	pp, err := core.NewPPManagerFactoryService(fabtoken.NewPPMFactory(), dlog.NewPPMFactory()).PublicParametersFromBytes(raw)
+	require.NoError(t, err)
+	checker, err := crl.NewCheckerFromExtras(pp.Extras())
+	require.NoError(t, err)
+	require.NotNil(t, checker)
+
+	return checker
+}
+
+// newCRL returns a PEM encoded list and the PEM encoded certificate of the CA that signed it
+func newCRL(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	der, err = x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now(),
+		NextUpdate: time.Now().Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: big.NewInt(2), RevocationTime: time.Now()},
+		},
+	}, ca, key)
+	require.NoError(t, err)
+	list, err := x509.ParseRevocationList(der)
+	require.NoError(t, err)
+
+	return crl.EncodeCRLs(list), crl.EncodeCAs(ca)
+}
@@ -25,3 +25,19 @@ func LoadIssuerPublicKey(idemixMSPDir string) (string, []byte, error) {
 
 	return path, ipkBytes, nil
 }
+
+// LoadRevocationPublicKey loads the PEM encoded Idemix revocation public key from the given MSP directory.
+// It returns nil if the directory holds no revocation public key.
+func LoadRevocationPublicKey(idemixMSPDir string) ([]byte, error) {
+	path := filepath.Join(idemixMSPDir, idemix.IdemixConfigDirMsp, idemix.IdemixConfigFileRevocationPublicKey)
+	rpk, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "failed reading idemix revocation public key [%s]", path)
+	}
+
+	return rpk, nil
+}
@@ -0,0 +1,374 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package proposal
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/pp/common"
+	"github.com/LFDT-Panurus/panurus/token/core"
+	fabtoken "github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/driver"
+	dlog "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/governance"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/sigscheme"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509"
+	"github.com/LFDT-Panurus/panurus/token/services/storage/db/kvs"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// PublicParamsFile is the name of the public parameters file written by submit
+	PublicParamsFile = "pp.json"
+	// ProposalFile is the name of the proposal file written by submit
+	ProposalFile = "proposal.json"
+)
+
+// Cmd returns the Cobra Command for managing public parameters update proposals.
+func Cmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "proposal",
+		Short: "Manage public parameters update proposals.",
+		Long: `Manage public parameters update proposals.
+Public parameters carrying a governance policy can only be replaced by parameters
+coming with a proposal signed by a quorum of the administrators listed in the policy.`,
+	}
+	c.AddCommand(policyCmd(), createCmd(), signCmd(), inspectCmd(), submitCmd())
+
+	return c
+}
+
+func policyCmd() *cobra.Command {
+	var (
+		admins []string
+		quorum int
+		output string
+	)
+	c := &cobra.Command{
+		Use:   "policy",
+		Short: "Generate a governance policy.",
+		Long:  "Generate a governance policy, to be added to the public parameters with --extra " + governance.PolicyKey + "=<file>.",
+		RunE: run(func() error {
+			return Policy(admins, quorum, output)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringSliceVarP(&admins, "admins", "a", nil, "list of administrator MSP directories containing the corresponding certificate")
+	flags.IntVarP(&quorum, "quorum", "q", 1, "number of administrators that must sign a proposal")
+	flags.StringVarP(&output, "output", "o", "policy.json", "output file")
+
+	return c
+}
+
+func createCmd() *cobra.Command {
+	var current, proposed, description, output string
+	c := &cobra.Command{
+		Use:   "create",
+		Short: "Create a proposal.",
+		Long:  "Create an unsigned proposal to replace the current public parameters with the proposed ones.",
+		RunE: run(func() error {
+			return Create(current, proposed, description, output)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&current, "current", "c", "", "path of the current public parameters file")
+	flags.StringVarP(&proposed, "proposed", "p", "", "path of the proposed public parameters file")
+	flags.StringVarP(&description, "description", "d", "", "description of the update")
+	flags.StringVarP(&output, "output", "o", ProposalFile, "output file")
+
+	return c
+}
+
+func signCmd() *cobra.Command {
+	var proposal, msp string
+	c := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign a proposal.",
+		Long:  "Add the signature of an administrator to a proposal. The proposal file is updated in place.",
+		RunE: run(func() error {
+			return Sign(proposal, msp)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&proposal, "proposal", "p", ProposalFile, "path of the proposal file")
+	flags.StringVarP(&msp, "msp", "m", "", "MSP directory of the administrator, with the certificate in signcerts and the key in keystore")
+
+	return c
+}
+
+func inspectCmd() *cobra.Command {
+	var proposal, current string
+	c := &cobra.Command{
+		Use:   "inspect",
+		Short: "Inspect a proposal.",
+		Long:  "Print the changes a proposal makes to the current public parameters and the state of its endorsements.",
+		RunE: run(func() error {
+			return Inspect(os.Stdout, proposal, current)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&proposal, "proposal", "p", ProposalFile, "path of the proposal file")
+	flags.StringVarP(&current, "current", "c", "", "path of the current public parameters file")
+
+	return c
+}
+
+func submitCmd() *cobra.Command {
+	var proposal, current, output string
+	c := &cobra.Command{
+		Use:   "submit",
+		Short: "Prepare an endorsed proposal for submission.",
+		Long: `Check that a proposal is endorsed by a quorum of administrators and write the proposed
+public parameters and the proposal to the output folder.
+With Fabric, deploy the token chaincode with the public parameters and pass the proposal to its init
+transaction, in the 'proposal' transient field or with the PUBLIC_PARAMS_PROPOSAL_FILE_PATH environment variable.`,
+		RunE: run(func() error {
+			return Submit(proposal, current, output)
+		}),
+	}
+	flags := c.Flags()
+	flags.StringVarP(&proposal, "proposal", "p", ProposalFile, "path of the proposal file")
+	flags.StringVarP(&current, "current", "c", "", "path of the current public parameters file")
+	flags.StringVarP(&output, "output", "o", ".", "output folder")
+
+	return c
+}
+
+func run(f func() error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return errors.New("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		return f()
+	}
+}
+
+// Policy writes to output a governance policy with the passed administrators and quorum
+func Policy(admins []string, quorum int, output string) error {
+	policy := &governance.Policy{Quorum: quorum}
+	for _, admin := range admins {
+		id, err := common.GetX509Identity(admin)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get administrator identity [%s]", admin)
+		}
+		policy.Administrators = append(policy.Administrators, id)
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.WithMessagef(err, "invalid governance policy")
+	}
+	raw, err := policy.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return writeFile(output, raw)
+}
+
+// Create writes to output an unsigned proposal to replace the current public parameters with the proposed ones
+func Create(current, proposed, description, output string) error {
+	currentRaw, currentPP, err := readPublicParameters(current)
+	if err != nil {
+		return err
+	}
+	if _, err := governance.PolicyFromPublicParameters(currentPP); err != nil {
+		return err
+	}
+	proposedRaw, proposedPP, err := readPublicParameters(proposed)
+	if err != nil {
+		return err
+	}
+	if err := proposedPP.Validate(); err != nil {
+		return errors.WithMessagef(err, "invalid proposed public parameters")
+	}
+	if _, err := governance.PolicyFromPublicParameters(proposedPP); err != nil {
+		return errors.WithMessagef(err, "invalid proposed public parameters")
+	}
+	raw, err := governance.NewProposal(currentRaw, proposedRaw, description).Bytes()
+	if err != nil {
+		return err
+	}
+
+	return writeFile(output, raw)
+}
+
+// Sign adds to the proposal the signature of the administrator whose MSP is found in msp
+func Sign(proposal, msp string) error {
+	p, err := readProposal(proposal)
+	if err != nil {
+		return err
+	}
+	id, signer, err := loadSigner(msp)
+	if err != nil {
+		return err
+	}
+	if err := p.Sign(id, signer); err != nil {
+		return err
+	}
+	raw, err := p.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(proposal, raw, 0o600); err != nil {
+		return errors.Wrapf(err, "failed writing proposal to [%s]", proposal)
+	}
+
+	return nil
+}
+
+// Inspect prints the changes and the endorsements of the proposal
+func Inspect(w io.Writer, proposal, current string) error {
+	p, err := readProposal(proposal)
+	if err != nil {
+		return err
+	}
+	id, err := p.ID()
+	if err != nil {
+		return err
+	}
+	currentRaw, currentPP, err := readPublicParameters(current)
+	if err != nil {
+		return err
+	}
+	proposedPP, err := ppReader().PublicParametersFromBytes(p.PublicParameters)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to unmarshal proposed public parameters")
+	}
+
+	_, _ = fmt.Fprintf(w, "Proposal: %s\n", id)
+	_, _ = fmt.Fprintf(w, "Description: %s\n", p.Description)
+	if h := sha256.Sum256(currentRaw); !bytes.Equal(h[:], p.CurrentPPHash) {
+		_, _ = fmt.Fprintf(w, "WARNING: the proposal updates different public parameters\n")
+	}
+	_, _ = fmt.Fprintf(w, "Changes:\n")
+	for _, change := range governance.Diff(currentPP, proposedPP) {
+		_, _ = fmt.Fprintf(w, "  %s\n", change)
+	}
+
+	policy, err := governance.PolicyFromPublicParameters(currentPP)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		_, _ = fmt.Fprintf(w, "Endorsements: [%d], the current public parameters have no governance policy\n", len(p.Endorsements))
+
+		return nil
+	}
+	endorsed, err := governance.NewValidator(ppReader(), governance.NewVerifierDeserializer()).Endorsed(context.Background(), policy, p)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "Endorsements: invalid, %s\n", err)
+
+		return nil
+	}
+	_, _ = fmt.Fprintf(w, "Endorsements: [%d] of [%d] required\n", len(endorsed), policy.Quorum)
+
+	return nil
+}
+
+// Submit checks that the proposal is endorsed by a quorum of administrators of the current public parameters,
+// and writes the proposed public parameters and the proposal to the output folder
+func Submit(proposal, current, output string) error {
+	p, err := readProposal(proposal)
+	if err != nil {
+		return err
+	}
+	currentRaw, _, err := readPublicParameters(current)
+	if err != nil {
+		return err
+	}
+	if err := governance.NewValidator(ppReader(), governance.NewVerifierDeserializer()).Validate(context.Background(), currentRaw, p); err != nil {
+		return errors.WithMessagef(err, "proposal rejected")
+	}
+	raw, err := p.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(output, PublicParamsFile), p.PublicParameters); err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(output, ProposalFile), raw)
+}
+
+// loadSigner loads the identity and the signer of the administrator whose MSP is found in msp
+func loadSigner(msp string) (driver.Identity, driver.Signer, error) {
+	id, err := common.GetX509Identity(msp)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "failed to get administrator identity [%s]", msp)
+	}
+	// Ed25519 and ML-DSA identities first, the x509 key manager supports only ECDSA keys
+	km, err := sigscheme.LoadKeyManager(msp)
+	if err == nil {
+		descriptor, err := km.Identity(context.Background(), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if descriptor.Signer == nil {
+			return nil, nil, errors.Errorf("no signing key found in [%s]", msp)
+		}
+
+		return id, descriptor.Signer, nil
+	}
+	if !errors.Is(err, sigscheme.ErrUnsupportedKey) {
+		return nil, nil, errors.WithMessagef(err, "failed to load administrator [%s]", msp)
+	}
+	x509km, _, err := x509.NewKeyManager(msp, nil, x509.NewKeyStore(kvs.NewTrackedMemory()))
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "failed to load administrator [%s]", msp)
+	}
+	if x509km.SigningIdentity() == nil {
+		return nil, nil, errors.Errorf("no signing key found in [%s]", msp)
+	}
+
+	return id, x509km.SigningIdentity(), nil
+}
+
+func ppReader() driver.PPReader {
+	return core.NewPPManagerFactoryService(fabtoken.NewPPMFactory(), dlog.NewPPMFactory())
+}
+
+func readPublicParameters(path string) ([]byte, driver.PublicParameters, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read public parameters at [%s]", path)
+	}
+	pp, err := ppReader().PublicParametersFromBytes(raw)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "failed to unmarshal public parameters at [%s]", path)
+	}
+
+	return raw, pp, nil
+}
+
+func readProposal(path string) (*governance.Proposal, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read proposal at [%s]", path)
+	}
+
+	return governance.ProposalFromBytes(raw)
+}
+
+func writeFile(path string, raw []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		return errors.Errorf("%s already exists, specify another output", path)
+	}
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		return errors.Wrapf(err, "failed writing [%s]", path)
+	}
+
+	return nil
+}
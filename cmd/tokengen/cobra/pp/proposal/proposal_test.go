@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package proposal
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/setup"
+	"github.com/LFDT-Panurus/panurus/token/services/governance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeMSP writes an MSP folder for the passed key in dir
+func writeMSP(t *testing.T, dir, cn string, sk crypto.Signer) {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, sk.Public(), sk)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "signcerts"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "signcerts", "cert.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	raw, err := x509.MarshalPKCS8PrivateKey(sk)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "keystore"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keystore", "priv_sk"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: raw}), 0o600))
+}
+
+func TestProposal(t *testing.T) {
+	dir := t.TempDir()
+
+	// administrators, one with an ECDSA key and one with an Ed25519 key
+	alice := filepath.Join(dir, "alice")
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	writeMSP(t, alice, "alice", ecdsaKey)
+	bob := filepath.Join(dir, "bob")
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	writeMSP(t, bob, "bob", ed25519Key)
+
+	// current public parameters, governed by alice and bob
+	policyFile := filepath.Join(dir, "policy.json")
+	require.Error(t, Policy([]string{alice, bob}, 3, policyFile))
+	require.NoError(t, Policy([]string{alice, bob}, 2, policyFile))
+	policyRaw, err := os.ReadFile(policyFile)
+	require.NoError(t, err)
+	pp, err := setup.Setup(64)
+	require.NoError(t, err)
+	pp.ExtraData[governance.PolicyKey] = policyRaw
+	current := filepath.Join(dir, "current.json")
+	raw, err := pp.Serialize()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(current, raw, 0o600))
+
+	// proposed public parameters, with a new issuer
+	pp.AddIssuer([]byte("issuer"))
+	proposed := filepath.Join(dir, "proposed.json")
+	raw, err = pp.Serialize()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(proposed, raw, 0o600))
+
+	proposal := filepath.Join(dir, "proposal.json")
+	require.NoError(t, Create(current, proposed, "add issuer", proposal))
+	require.NoError(t, Sign(proposal, alice))
+	out := filepath.Join(dir, "out")
+	require.NoError(t, os.MkdirAll(out, 0o750))
+	require.ErrorContains(t, Submit(proposal, current, out), "not enough endorsements")
+
+	require.NoError(t, Sign(proposal, bob))
+	var buf bytes.Buffer
+	require.NoError(t, Inspect(&buf, proposal, current))
+	assert.Contains(t, buf.String(), "Description: add issuer")
+	assert.Contains(t, buf.String(), "issuers: + ")
+	assert.Contains(t, buf.String(), "Endorsements: [2] of [2] required")
+	assert.NotContains(t, buf.String(), "WARNING")
+
+	require.NoError(t, Submit(proposal, current, out))
+	ppRaw, err := os.ReadFile(filepath.Join(out, PublicParamsFile))
+	require.NoError(t, err)
+	assert.Equal(t, raw, ppRaw)
+	_, err = os.Stat(filepath.Join(out, ProposalFile))
+	require.NoError(t, err)
+
+	// the proposal cannot be submitted against other public parameters
+	require.ErrorContains(t, Submit(proposal, proposed, t.TempDir()), "updates different public parameters")
+}
+
+func TestCmd(t *testing.T) {
+	cmd := Cmd()
+	assert.Len(t, cmd.Commands(), 5)
+	cmd.SetArgs([]string{"inspect", "--proposal", "nonexistent.json", "extra"})
+	require.ErrorContains(t, cmd.Execute(), "trailing args detected")
+}
@@ -7,14 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package pp
 
 import (
+	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/pp/crl"
 	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/pp/zkatdlognoghv1"
 	"github.com/spf13/cobra"
 )
 
 // UpdateCmd returns the Cobra Command for updating certificates in the public parameters file.
 func UpdateCmd() *cobra.Command {
-	// not implemented for fabtoken
-	updateCobraCommand.AddCommand(zkatdlognoghv1.UpdateCmd())
+	// certificates update not implemented for fabtoken
+	updateCobraCommand.AddCommand(zkatdlognoghv1.UpdateCmd(), crl.Cmd())
 
 	return updateCobraCommand
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/LFDT-Panurus/panurus/integration/nwo/token/generators/crypto/zkatdlognoghv1"
 	setupv1 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/setup"
 	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix/revocation"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 	"github.com/spf13/cobra"
 )
@@ -155,6 +156,23 @@ func Gen(args *GeneratorArgs) ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed loading extras")
 	}
+	// pin the revocation public key of the idemix issuer, revocation lists are verified against it
+	if _, ok := pp.ExtraData[revocation.KeysExtrasKey]; !ok {
+		rpk, err := idemix.LoadRevocationPublicKey(args.IdemixMSPDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load revocation public key")
+		}
+		if len(rpk) != 0 {
+			keys := revocation.Keys{}
+			if err := keys.Pin(ipkBytes, rpk); err != nil {
+				return nil, errors.Wrap(err, "failed to pin revocation public key")
+			}
+			pp.ExtraData[revocation.KeysExtrasKey], err = keys.Bytes()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal revocation public keys")
+			}
+		}
+	}
 
 	// validate
 	if err := pp.Validate(); err != nil {
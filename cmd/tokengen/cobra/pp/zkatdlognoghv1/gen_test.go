@@ -7,10 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package zkatdlognoghv1
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
 	"path/filepath"
 	"testing"
 
+	setupv1 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/setup"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix/revocation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -114,6 +121,31 @@ func TestGen(t *testing.T) {
 		assert.NotNil(t, raw)
 		assert.FileExists(t, filepath.Join(tempDir, "zkatdlognoghv2_pp.json"))
 	})
+
+	t.Run("success_revocation_key", func(t *testing.T) {
+		sk, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+		rpk, err := x509.MarshalPKIXPublicKey(sk.Public())
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(idemixDir, "msp", "RevocationPublicKey"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rpk}), 0644)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, os.Remove(filepath.Join(idemixDir, "msp", "RevocationPublicKey"))) }()
+
+		args := &GeneratorArgs{
+			IdemixMSPDir: idemixDir,
+			OutputDir:    t.TempDir(),
+			BitLength:    64,
+		}
+		raw, err := Gen(args)
+		require.NoError(t, err)
+		pp, err := setupv1.NewPublicParamsFromBytes(raw, setupv1.DLogNoGHDriverName, setupv1.ProtocolV1)
+		require.NoError(t, err)
+		keys, err := revocation.KeysFromExtras(pp.Extras())
+		require.NoError(t, err)
+		pk, err := keys.PublicKey(revocation.IssuerHash([]byte("dummy ipk")))
+		require.NoError(t, err)
+		assert.True(t, pk.Equal(sk.Public()))
+	})
 }
 
 // generateZKATTestCertificate generates a test certificate.
@@ -0,0 +1,338 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package request
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/LFDT-Panurus/panurus/token/core"
+	fabtoken "github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/driver"
+	dlog "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/driver/protos-go/v1/request"
+	"github.com/LFDT-Panurus/panurus/token/token"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// EncodingAuto detects whether the token request is hex, base64 or raw encoded
+	EncodingAuto = "auto"
+	// EncodingRaw is the encoding of a token request in its protobuf form
+	EncodingRaw = "raw"
+	// EncodingBase64 is the encoding of a base64 encoded token request
+	EncodingBase64 = "base64"
+	// EncodingHex is the encoding of a hex encoded token request
+	EncodingHex = "hex"
+
+	// FormatJSON prints the decoded token request as JSON
+	FormatJSON = "json"
+	// FormatYAML prints the decoded token request as YAML
+	FormatYAML = "yaml"
+)
+
+// Snapshot is a ledger snapshot to validate token requests against
+type Snapshot struct {
+	// States are the tokens on the ledger
+	States []State `json:"states"`
+}
+
+// State is a token on the ledger
+type State struct {
+	// ID is the identifier of the token
+	ID token.ID `json:"id"`
+	// Raw is the token as stored on the ledger, base64 encoded
+	Raw []byte `json:"raw"`
+}
+
+// DecodeArgs defines the arguments for the token request decoder.
+type DecodeArgs struct {
+	// InputFile is the file that contains the token request, `-` for the standard input.
+	InputFile string
+	// Request is the encoded token request, used if InputFile is empty.
+	Request string
+	// Encoding is the encoding of the token request, one of auto, raw, base64 or hex.
+	Encoding string
+	// PublicParamsFile is the file that contains the public parameters. Without it, actions are not decoded.
+	PublicParamsFile string
+	// PublicParamsHash is the expected hex encoded SHA-256 hash of the public parameters.
+	PublicParamsHash string
+	// Format is the output format, json or yaml.
+	Format string
+	// Verify runs the validator of the token driver on the token request.
+	Verify bool
+	// LedgerFile is the file that contains the ledger Snapshot to validate the token request against.
+	LedgerFile string
+	// Anchor is the anchor of the token request, usually the transaction id.
+	Anchor string
+}
+
+// Cmd returns the Cobra Command for inspecting token requests.
+func Cmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "request",
+		Short: "Inspect token requests.",
+		Long:  `Inspect token requests.`,
+	}
+	c.AddCommand(decodeCmd())
+
+	return c
+}
+
+func decodeCmd() *cobra.Command {
+	args := &DecodeArgs{}
+	c := &cobra.Command{
+		Use:   "decode",
+		Short: "Decode a token request.",
+		Long: `Decode a token request and print its actions, inputs, outputs, issuers, signatures and metadata keys.
+With the public parameters, the actions are decoded with the corresponding token driver
+and, with --verify, the token request is validated against the ledger snapshot passed with --ledger.
+A ledger snapshot is a JSON file of the form {"states": [{"id": {"tx_id": "...", "index": 0}, "raw": "<base64>"}]}.`,
+		RunE: func(cmd *cobra.Command, a []string) error {
+			if len(a) != 0 {
+				return errors.New("trailing args detected")
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			return Decode(context.Background(), os.Stdout, args)
+		},
+	}
+	flags := c.Flags()
+	flags.StringVarP(&args.InputFile, "input", "i", "", "path of the token request file, - for the standard input")
+	flags.StringVarP(&args.Request, "request", "r", "", "base64 or hex encoded token request, used if --input is not set")
+	flags.StringVarP(&args.Encoding, "encoding", "e", EncodingAuto, "encoding of the token request: auto, raw, base64 or hex")
+	flags.StringVarP(&args.PublicParamsFile, "pp", "p", "", "path of the public parameters file")
+	flags.StringVarP(&args.PublicParamsHash, "pp-hash", "", "", "expected hex encoded SHA-256 hash of the public parameters")
+	flags.StringVarP(&args.Format, "format", "f", FormatJSON, "output format: json or yaml")
+	flags.BoolVarP(&args.Verify, "verify", "v", false, "validate the token request, requires --pp, --ledger and --anchor")
+	flags.StringVarP(&args.LedgerFile, "ledger", "l", "", "path of the ledger snapshot file")
+	flags.StringVarP(&args.Anchor, "anchor", "a", "", "anchor of the token request, usually the transaction id")
+
+	return c
+}
+
+// Decode writes to w a human-readable view of the token request described by args.
+// If the token request is verified and found invalid, Decode writes the view and returns an error.
+func Decode(ctx context.Context, w io.Writer, args *DecodeArgs) error {
+	if args.Format != FormatJSON && args.Format != FormatYAML {
+		return errors.Errorf("invalid format [%s], expected json or yaml", args.Format)
+	}
+	raw, err := readRequest(args)
+	if err != nil {
+		return err
+	}
+	tr := &driver.TokenRequest{}
+	if err := tr.FromBytes(raw); err != nil {
+		return errors.Wrap(err, "failed to unmarshal token request")
+	}
+
+	view := &Request{Version: tr.Version}
+	for i, action := range tr.Actions {
+		view.Actions = append(view.Actions, &Action{Index: i, Type: actionType(action.Type), Size: len(action.Raw)})
+	}
+	for _, sig := range tr.Signatures {
+		switch {
+		case sig == nil:
+			continue
+		case sig.Auditor != nil:
+			view.Signatures = append(view.Signatures, &Signature{Auditor: newIdentity(sig.Auditor.Identity), Size: len(sig.Auditor.Signature)})
+		case sig.Action != nil:
+			view.Signatures = append(view.Signatures, &Signature{Action: &sig.Action.ActionID, Size: len(sig.Action.Signature)})
+		}
+	}
+
+	if len(args.PublicParamsFile) != 0 {
+		validator, err := decodeActions(view, tr, raw, args)
+		if err != nil {
+			return err
+		}
+		if args.Verify {
+			view.Verification, err = verify(ctx, validator, raw, args)
+			if err != nil {
+				return err
+			}
+		}
+	} else if args.Verify {
+		return errors.New("verification requires the public parameters")
+	}
+
+	if err := write(w, view, args.Format); err != nil {
+		return err
+	}
+	if view.Verification != nil && !view.Verification.Valid {
+		return errors.Errorf("token request is not valid: %s", view.Verification.Error)
+	}
+
+	return nil
+}
+
+// decodeActions fills view with the actions of tr decoded with the driver of the public parameters, and returns the driver's validator
+func decodeActions(view *Request, tr *driver.TokenRequest, raw []byte, args *DecodeArgs) (driver.Validator, error) {
+	ppRaw, err := os.ReadFile(args.PublicParamsFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read public parameters at [%s]", args.PublicParamsFile)
+	}
+	s := core.NewValidatorDriverService(fabtoken.NewValidatorDriver(), dlog.NewValidatorDriver())
+	pp, err := s.PublicParametersFromBytes(ppRaw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal public parameters from [%s]", args.PublicParamsFile)
+	}
+	h := sha256.Sum256(ppRaw)
+	view.PublicParams = &PublicParams{
+		Driver: string(core.DriverIdentifierFromPP(pp)),
+		Hash:   hex.EncodeToString(h[:]),
+	}
+	if len(args.PublicParamsHash) != 0 {
+		view.PublicParams.ExpectedHash = strings.ToLower(strings.TrimSpace(args.PublicParamsHash))
+		match := view.PublicParams.ExpectedHash == view.PublicParams.Hash
+		view.PublicParams.HashMatch = &match
+	}
+
+	validator, err := s.NewValidator(pp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to instantiate validator")
+	}
+	actions, err := validator.UnmarshalActions(raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal actions")
+	}
+	if len(actions) != len(tr.Actions) {
+		return nil, errors.Errorf("expected [%d] actions, got [%d]", len(tr.Actions), len(actions))
+	}
+	// the validator returns the issue actions first and then the transfer actions
+	issues, transfers := actions[:tr.NumIssues()], actions[tr.NumIssues():]
+	for i, action := range tr.Actions {
+		switch action.Type {
+		case request.ActionType_ACTION_TYPE_ISSUE:
+			fill(view.Actions[i], issues[0])
+			issues = issues[1:]
+		case request.ActionType_ACTION_TYPE_TRANSFER:
+			fill(view.Actions[i], transfers[0])
+			transfers = transfers[1:]
+		}
+	}
+
+	return validator, nil
+}
+
+// verify validates the token request against the ledger snapshot in args
+func verify(ctx context.Context, validator driver.Validator, raw []byte, args *DecodeArgs) (*Verification, error) {
+	if len(args.Anchor) == 0 {
+		return nil, errors.New("verification requires the anchor")
+	}
+	snapshot := &Snapshot{}
+	if len(args.LedgerFile) != 0 {
+		snapshotRaw, err := os.ReadFile(args.LedgerFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read ledger snapshot at [%s]", args.LedgerFile)
+		}
+		if err := json.Unmarshal(snapshotRaw, snapshot); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal ledger snapshot from [%s]", args.LedgerFile)
+		}
+	}
+	getState := func(id token.ID) ([]byte, error) {
+		for _, state := range snapshot.States {
+			if state.ID.Equal(id) {
+				return state.Raw, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	v := &Verification{Anchor: args.Anchor, Valid: true}
+	_, attributes, err := validator.VerifyTokenRequestFromRaw(ctx, getState, driver.TokenRequestAnchor(args.Anchor), raw)
+	if err != nil {
+		v.Valid = false
+		v.Error = err.Error()
+	}
+	for k := range attributes {
+		v.Attributes = append(v.Attributes, string(k))
+	}
+	slices.Sort(v.Attributes)
+
+	return v, nil
+}
+
+// readRequest returns the token request in its protobuf form
+func readRequest(args *DecodeArgs) ([]byte, error) {
+	var raw []byte
+	var err error
+	switch args.InputFile {
+	case "":
+		if len(args.Request) == 0 {
+			return nil, errors.New("no token request passed, use --input or --request")
+		}
+		raw = []byte(args.Request)
+	case "-":
+		raw, err = io.ReadAll(os.Stdin)
+	default:
+		raw, err = os.ReadFile(args.InputFile)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read token request at [%s]", args.InputFile)
+	}
+
+	text := strings.TrimSpace(string(raw))
+	switch args.Encoding {
+	case EncodingRaw:
+		return raw, nil
+	case EncodingBase64:
+		return base64.StdEncoding.DecodeString(text)
+	case EncodingHex:
+		return hex.DecodeString(text)
+	case EncodingAuto:
+		if decoded, err := hex.DecodeString(text); err == nil {
+			return decoded, nil
+		}
+		if decoded, err := base64.StdEncoding.DecodeString(text); err == nil {
+			return decoded, nil
+		}
+
+		return raw, nil
+	default:
+		return nil, errors.Errorf("invalid encoding [%s], expected auto, raw, base64 or hex", args.Encoding)
+	}
+}
+
+func actionType(t request.ActionType) string {
+	switch t {
+	case request.ActionType_ACTION_TYPE_ISSUE:
+		return "issue"
+	case request.ActionType_ACTION_TYPE_TRANSFER:
+		return "transfer"
+	default:
+		return t.String()
+	}
+}
+
+func write(w io.Writer, view *Request, format string) error {
+	var out []byte
+	var err error
+	if format == FormatYAML {
+		out, err = yaml.Marshal(view)
+	} else {
+		out, err = json.MarshalIndent(view, "", "  ")
+		out = append(out, '\n')
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal token request view")
+	}
+	_, err = w.Write(out)
+
+	return err
+}
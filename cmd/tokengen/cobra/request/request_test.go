@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package request
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/actions"
+	"github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/setup"
+	"github.com/LFDT-Panurus/panurus/token/driver"
+	request2 "github.com/LFDT-Panurus/panurus/token/driver/protos-go/v1/request"
+	"github.com/LFDT-Panurus/panurus/token/services/identity"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	pp, err := setup.Setup(64)
+	require.NoError(t, err)
+	ppRaw, err := pp.Serialize()
+	require.NoError(t, err)
+	ppFile := filepath.Join(dir, "pp.json")
+	require.NoError(t, os.WriteFile(ppFile, ppRaw, 0o600))
+	h := sha256.Sum256(ppRaw)
+
+	issuer, err := identity.WrapWithType(x509.IdentityType, []byte("issuer"))
+	require.NoError(t, err)
+	owner, err := identity.WrapWithType(x509.IdentityType, []byte("alice"))
+	require.NoError(t, err)
+	issue := &actions.IssueAction{
+		Issuer:   issuer,
+		Outputs:  []*actions.Output{{Owner: owner, Type: "USD", Quantity: "0x0a"}},
+		Metadata: map[string][]byte{"memo": []byte("hello")},
+	}
+	issueRaw, err := issue.Serialize()
+	require.NoError(t, err)
+	tr := &driver.TokenRequest{
+		Version: driver.ProtocolV1,
+		Actions: []*driver.TypedAction{{Type: request2.ActionType_ACTION_TYPE_ISSUE, Raw: issueRaw}},
+		Signatures: []*driver.RequestSignature{
+			{Action: &driver.ActionSignature{ActionID: 0, Signature: []byte("signature")}},
+		},
+	}
+	raw, err := tr.Bytes()
+	require.NoError(t, err)
+
+	// without public parameters only the envelope is decoded
+	var buf bytes.Buffer
+	require.NoError(t, Decode(ctx, &buf, &DecodeArgs{Request: base64.StdEncoding.EncodeToString(raw), Encoding: EncodingAuto, Format: FormatJSON}))
+	view := &Request{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), view))
+	assert.Equal(t, uint32(driver.ProtocolV1), view.Version)
+	require.Len(t, view.Actions, 1)
+	assert.Equal(t, "issue", view.Actions[0].Type)
+	assert.Nil(t, view.Actions[0].Issuer)
+	require.Len(t, view.Signatures, 1)
+	assert.Equal(t, uint32(0), *view.Signatures[0].Action)
+
+	// with public parameters the actions are decoded
+	requestFile := filepath.Join(dir, "request.hex")
+	require.NoError(t, os.WriteFile(requestFile, []byte(hex.EncodeToString(raw)), 0o600))
+	buf.Reset()
+	require.NoError(t, Decode(ctx, &buf, &DecodeArgs{
+		InputFile:        requestFile,
+		Encoding:         EncodingAuto,
+		PublicParamsFile: ppFile,
+		PublicParamsHash: hex.EncodeToString(h[:]),
+		Format:           FormatJSON,
+	}))
+	view = &Request{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), view))
+	assert.True(t, *view.PublicParams.HashMatch)
+	action := view.Actions[0]
+	assert.Equal(t, "x509", action.Issuer.Type)
+	assert.Equal(t, []string{"memo"}, action.MetadataKeys)
+	require.Len(t, action.Outputs, 1)
+	assert.Equal(t, "USD", action.Outputs[0].Type)
+	assert.Equal(t, "0x0a", action.Outputs[0].Quantity)
+	assert.Equal(t, driver.Identity(owner).String(), action.Outputs[0].Owner.ID)
+
+	// yaml output
+	buf.Reset()
+	require.NoError(t, Decode(ctx, &buf, &DecodeArgs{InputFile: requestFile, Encoding: EncodingHex, PublicParamsFile: ppFile, PublicParamsHash: "00", Format: FormatYAML}))
+	assert.Contains(t, buf.String(), "hash_match: false")
+	assert.Contains(t, buf.String(), "type: USD")
+
+	// the issuer is not authorized by the public parameters
+	buf.Reset()
+	err = Decode(ctx, &buf, &DecodeArgs{InputFile: requestFile, Encoding: EncodingAuto, PublicParamsFile: ppFile, Format: FormatJSON, Verify: true, Anchor: "tx1"})
+	require.ErrorContains(t, err, "token request is not valid")
+	view = &Request{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), view))
+	assert.False(t, view.Verification.Valid)
+	assert.NotEmpty(t, view.Verification.Error)
+
+	require.ErrorContains(t, Decode(ctx, &buf, &DecodeArgs{InputFile: requestFile, Encoding: EncodingAuto, Format: FormatJSON, Verify: true}), "requires the public parameters")
+	require.ErrorContains(t, Decode(ctx, &buf, &DecodeArgs{InputFile: requestFile, Encoding: EncodingAuto, Format: "xml"}), "invalid format")
+}
+
+func TestCmd(t *testing.T) {
+	cmd := Cmd()
+	assert.Len(t, cmd.Commands(), 1)
+	cmd.SetArgs([]string{"decode", "--input", "nonexistent", "extra"})
+	require.ErrorContains(t, cmd.Execute(), "trailing args detected")
+}
@@ -0,0 +1,248 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package request
+
+import (
+	"encoding/hex"
+	"slices"
+
+	"github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/actions"
+	dlog "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/token"
+	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity"
+)
+
+// Request is the human-readable view of a token request
+type Request struct {
+	// Version is the protocol version of the request
+	Version uint32 `json:"version" yaml:"version"`
+	// PublicParams describes the public parameters used to decode the actions, if any
+	PublicParams *PublicParams `json:"public_params,omitempty" yaml:"public_params,omitempty"`
+	// Actions are the actions of the request.
+	// Without public parameters, only their type and size are known.
+	Actions []*Action `json:"actions" yaml:"actions"`
+	// Signatures are the signatures attached to the request
+	Signatures []*Signature `json:"signatures" yaml:"signatures"`
+	// Verification is the outcome of the validation of the request, if requested
+	Verification *Verification `json:"verification,omitempty" yaml:"verification,omitempty"`
+}
+
+// PublicParams describes public parameters
+type PublicParams struct {
+	// Driver is the identifier of the token driver
+	Driver string `json:"driver" yaml:"driver"`
+	// Hash is the hex encoded SHA-256 hash of the serialized public parameters
+	Hash string `json:"hash" yaml:"hash"`
+	// ExpectedHash is the hash the public parameters were expected to have, if any
+	ExpectedHash string `json:"expected_hash,omitempty" yaml:"expected_hash,omitempty"`
+	// HashMatch tells if Hash equals ExpectedHash, set only if ExpectedHash is
+	HashMatch *bool `json:"hash_match,omitempty" yaml:"hash_match,omitempty"`
+}
+
+// Action is the view of an issue or transfer action
+type Action struct {
+	// Index is the position of the action in the request, signatures refer to it
+	Index int `json:"index" yaml:"index"`
+	// Type is either issue or transfer
+	Type string `json:"type" yaml:"type"`
+	// Size is the size in bytes of the serialized action
+	Size int `json:"size" yaml:"size"`
+	// Issuer is the issuer of an issue action, or of the tokens redeemed by a transfer action
+	Issuer *Identity `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	// Anonymous tells if the issuer is hidden
+	Anonymous bool `json:"anonymous,omitempty" yaml:"anonymous,omitempty"`
+	// GraphHiding tells if the inputs are referenced by serial number
+	GraphHiding bool `json:"graph_hiding,omitempty" yaml:"graph_hiding,omitempty"`
+	// Inputs are the tokens spent by the action
+	Inputs []*Input `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	// Outputs are the tokens created by the action
+	Outputs []*Token `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	// ExtraSigners are the identities that must sign the action besides the issuer and the input owners
+	ExtraSigners []*Identity `json:"extra_signers,omitempty" yaml:"extra_signers,omitempty"`
+	// MetadataKeys are the keys of the action metadata
+	MetadataKeys []string `json:"metadata_keys,omitempty" yaml:"metadata_keys,omitempty"`
+}
+
+// Input is the view of a spent token
+type Input struct {
+	// ID is the identifier of the token, empty if the action is graph hiding
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+	// SerialNumber is the serial number of the token, if the action is graph hiding
+	SerialNumber string `json:"serial_number,omitempty" yaml:"serial_number,omitempty"`
+	// Token is the spent token, if carried by the action
+	Token *Token `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
+// Token is the view of a token: in the clear, or as a commitment to its type and quantity
+type Token struct {
+	// Owner is the owner of the token, nil for redeemed tokens
+	Owner *Identity `json:"owner,omitempty" yaml:"owner,omitempty"`
+	// Redeem tells if the token is redeemed
+	Redeem bool `json:"redeem,omitempty" yaml:"redeem,omitempty"`
+	// Type is the type of a cleartext token
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Quantity is the quantity of a cleartext token
+	Quantity string `json:"quantity,omitempty" yaml:"quantity,omitempty"`
+	// Commitment is the hex encoded commitment to type and quantity of an obfuscated token
+	Commitment string `json:"commitment,omitempty" yaml:"commitment,omitempty"`
+}
+
+// Identity is the view of an identity
+type Identity struct {
+	// Type is the identity type, e.g. x509 or idemix
+	Type string `json:"type" yaml:"type"`
+	// ID is the unique identifier of the identity, as reported by the logs
+	ID string `json:"id" yaml:"id"`
+	// Size is the size in bytes of the serialized identity
+	Size int `json:"size" yaml:"size"`
+}
+
+// Signature is the view of a signature
+type Signature struct {
+	// Action is the index of the signed action, nil for auditor signatures
+	Action *uint32 `json:"action,omitempty" yaml:"action,omitempty"`
+	// Auditor is the auditor that produced the signature, nil for action signatures
+	Auditor *Identity `json:"auditor,omitempty" yaml:"auditor,omitempty"`
+	// Size is the size in bytes of the signature
+	Size int `json:"size" yaml:"size"`
+}
+
+// Verification is the outcome of the validation of a request
+type Verification struct {
+	// Anchor is the anchor the request was validated against
+	Anchor string `json:"anchor" yaml:"anchor"`
+	// Valid tells if the request is valid
+	Valid bool `json:"valid" yaml:"valid"`
+	// Error is the reason why the request is not valid
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Attributes are the keys of the validation attributes returned by the validator
+	Attributes []string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+func newIdentity(id driver.Identity) *Identity {
+	if id.IsNone() {
+		return nil
+	}
+	view := &Identity{ID: id.String(), Size: len(id), Type: "unknown"}
+	if typed, err := identity.UnmarshalTypedIdentity(id); err == nil {
+		view.Type = identity.TypeToString(typed.Type)
+	}
+
+	return view
+}
+
+func newIdentities(ids []driver.Identity) []*Identity {
+	views := make([]*Identity, 0, len(ids))
+	for _, id := range ids {
+		if v := newIdentity(id); v != nil {
+			views = append(views, v)
+		}
+	}
+
+	return views
+}
+
+// newToken decodes a serialized fabtoken or zkatdlog token, it returns nil if raw is neither
+func newToken(raw []byte) *Token {
+	if len(raw) == 0 {
+		return nil
+	}
+	cleartext := &actions.Output{}
+	if err := cleartext.Deserialize(raw); err == nil {
+		return &Token{
+			Owner:    newIdentity(cleartext.Owner),
+			Redeem:   cleartext.IsRedeem(),
+			Type:     string(cleartext.Type),
+			Quantity: cleartext.Quantity,
+		}
+	}
+	obfuscated := &dlog.Token{}
+	if err := obfuscated.Deserialize(raw); err == nil {
+		view := &Token{
+			Owner:  newIdentity(obfuscated.Owner),
+			Redeem: obfuscated.IsRedeem(),
+		}
+		if obfuscated.Data != nil {
+			view.Commitment = hex.EncodeToString(obfuscated.Data.Bytes())
+		}
+
+		return view
+	}
+
+	return nil
+}
+
+func newInputs(action driver.ActionWithInputs) []*Input {
+	ids := action.GetInputs()
+	sns := action.GetSerialNumbers()
+	tokens, err := action.GetSerializedInputs()
+	if err != nil {
+		tokens = nil
+	}
+	n := max(len(ids), len(sns), len(tokens))
+	inputs := make([]*Input, n)
+	for i := range n {
+		inputs[i] = &Input{}
+		if i < len(ids) && ids[i] != nil {
+			inputs[i].ID = ids[i].String()
+		}
+		if i < len(sns) {
+			inputs[i].SerialNumber = sns[i]
+		}
+		if i < len(tokens) {
+			inputs[i].Token = newToken(tokens[i])
+		}
+	}
+
+	return inputs
+}
+
+func newOutputs(outputs []driver.Output) []*Token {
+	views := make([]*Token, 0, len(outputs))
+	for _, output := range outputs {
+		raw, err := output.Serialize()
+		if err != nil {
+			views = append(views, &Token{Owner: newIdentity(output.GetOwner()), Redeem: output.IsRedeem()})
+
+			continue
+		}
+		views = append(views, newToken(raw))
+	}
+
+	return views
+}
+
+func metadataKeys(metadata map[string][]byte) []string {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// fill completes the passed action view with the content of the unmarshalled action
+func fill(view *Action, action any) {
+	switch a := action.(type) {
+	case driver.IssueAction:
+		view.Issuer = newIdentity(a.GetIssuer())
+		view.Anonymous = a.IsAnonymous()
+		view.GraphHiding = a.IsGraphHiding()
+		view.Inputs = newInputs(a)
+		view.Outputs = newOutputs(a.GetOutputs())
+		view.ExtraSigners = newIdentities(a.ExtraSigners())
+		view.MetadataKeys = metadataKeys(a.GetMetadata())
+	case driver.TransferAction:
+		view.Issuer = newIdentity(a.GetIssuer())
+		view.GraphHiding = a.IsGraphHiding()
+		view.Inputs = newInputs(a)
+		view.Outputs = newOutputs(a.GetOutputs())
+		view.ExtraSigners = newIdentities(a.ExtraSigners())
+		view.MetadataKeys = metadataKeys(a.GetMetadata())
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"strings"
 
 	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/certfier"
+	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/identity"
 	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/pp"
+	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/pp/proposal"
+	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/request"
 	"github.com/LFDT-Panurus/panurus/cmd/tokengen/cobra/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -55,7 +58,10 @@ func Execute() error {
 	mainCmd.AddCommand(pp.GenCmd())
 	mainCmd.AddCommand(pp.UpdateCmd())
 	mainCmd.AddCommand(pp.UtilsCmd())
+	mainCmd.AddCommand(proposal.Cmd())
+	mainCmd.AddCommand(request.Cmd())
 	mainCmd.AddCommand(certfier.KeyPairGenCmd())
+	mainCmd.AddCommand(identity.Cmd())
 	mainCmd.AddCommand(version.Cmd())
 
 	return mainCmd.Execute()
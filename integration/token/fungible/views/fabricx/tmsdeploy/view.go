@@ -19,6 +19,8 @@ type Deploy struct {
 	Channel         string
 	Namespace       string
 	PublicParamsRaw []byte
+	// Proposal is the governance proposal authorizing the public parameters, if they replace governed ones
+	Proposal []byte
 }
 
 type View struct {
@@ -31,14 +33,16 @@ func (f *View) Call(ctx view.Context) (any, error) {
 		return nil, errors.WithMessagef(err, "deployer service not found")
 	}
 
-	return nil, deployerService.DeployTMSWithPP(
-		token.TMSID{
-			Network:   f.Network,
-			Channel:   f.Channel,
-			Namespace: f.Namespace,
-		},
-		f.PublicParamsRaw,
-	)
+	tmsID := token.TMSID{
+		Network:   f.Network,
+		Channel:   f.Channel,
+		Namespace: f.Namespace,
+	}
+	if len(f.Proposal) != 0 {
+		return nil, deployerService.DeployTMSWithProposal(tmsID, f.PublicParamsRaw, f.Proposal)
+	}
+
+	return nil, deployerService.DeployTMSWithPP(tmsID, f.PublicParamsRaw)
 }
 
 type ViewFactory struct{}
@@ -56,6 +56,23 @@ type ValidateIssueFunc[P driver.PublicParameters, T driver.Input, TA driver.Tran
 // ValidateAuditingFunc is a function type for validating auditing information.
 type ValidateAuditingFunc[P driver.PublicParameters, T driver.Input, TA driver.TransferAction, IA driver.IssueAction, DS driver.Deserializer] func(c context.Context, ctx *Context[P, T, TA, IA, DS]) error
 
+// DeferredChecks are checks postponed during the validation of a token request.
+type DeferredChecks interface {
+	// Verify runs the postponed checks and returns an error if any of them fails.
+	Verify() error
+}
+
+// ChecksBatcher lets a driver postpone some of the checks performed by its validators
+// so that the checks of many token requests can be run at once.
+type ChecksBatcher interface {
+	// WithDeferredChecks returns a context instructing the validators to postpone their checks,
+	// and the checks postponed via that context.
+	WithDeferredChecks(ctx context.Context) (context.Context, DeferredChecks, error)
+	// VerifyDeferredChecks runs all the passed checks at once.
+	// It returns an error if at least one of them fails, without telling which.
+	VerifyDeferredChecks(checks []DeferredChecks) error
+}
+
 // Validator validates token requests.
 type Validator[P driver.PublicParameters, T driver.Input, TA driver.TransferAction, IA driver.IssueAction, DS driver.Deserializer] struct {
 	Logger             logging.Logger
@@ -72,6 +89,9 @@ type Validator[P driver.PublicParameters, T driver.Input, TA driver.TransferActi
 	// If set to a specific version (e.g., driver.ProtocolV1), only requests with that version
 	// or higher will be accepted, rejecting older protocol versions.
 	MinProtocolVersion uint32
+
+	// ChecksBatcher, if not nil, is used by VerifyTokenRequestsFromRaw to run the checks of many requests at once.
+	ChecksBatcher ChecksBatcher
 }
 
 // NewValidator returns a new Validator instance for the passed arguments.
@@ -166,6 +186,63 @@ func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequestFromRaw(ctx context.Cont
 	return v.VerifyTokenRequest(ctx, backend, backend, anchor, tr, attributes)
 }
 
+// VerifyTokenRequestsFromRaw verifies many token requests from their raw representation.
+// If a ChecksBatcher is available, the checks it can postpone are run at once for all the requests
+// that passed the other checks. If the joint check fails, the postponed checks of each request are run
+// separately to find the invalid ones.
+func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequestsFromRaw(ctx context.Context, requests []driver.TokenRequestToVerify) []driver.TokenRequestVerification {
+	results := make([]driver.TokenRequestVerification, len(requests))
+	if v.ChecksBatcher == nil || len(requests) < 2 {
+		for i, r := range requests {
+			results[i].Actions, results[i].Attributes, results[i].Err = v.VerifyTokenRequestFromRaw(requestContext(ctx, r), r.GetState, r.Anchor, r.Raw)
+		}
+
+		return results
+	}
+
+	checks := make([]DeferredChecks, 0, len(requests))
+	pending := make([]int, 0, len(requests))
+	for i, r := range requests {
+		rctx, c, err := v.ChecksBatcher.WithDeferredChecks(requestContext(ctx, r))
+		if err != nil {
+			results[i].Err = errors.Wrapf(err, "failed to prepare deferred checks [%s]", r.Anchor)
+
+			continue
+		}
+		results[i].Actions, results[i].Attributes, results[i].Err = v.VerifyTokenRequestFromRaw(rctx, r.GetState, r.Anchor, r.Raw)
+		if results[i].Err == nil {
+			checks = append(checks, c)
+			pending = append(pending, i)
+		}
+	}
+	if len(checks) == 0 {
+		return results
+	}
+	err := v.ChecksBatcher.VerifyDeferredChecks(checks)
+	if err == nil {
+		return results
+	}
+	logger.DebugfContext(ctx, "deferred checks of [%d] requests failed, verify them one by one: %v", len(checks), err)
+	for j, i := range pending {
+		if err := checks[j].Verify(); err != nil {
+			results[i] = driver.TokenRequestVerification{
+				Err: errors.Wrapf(err, "failed to verify deferred checks [%s]", requests[i].Anchor),
+			}
+		}
+	}
+
+	return results
+}
+
+// requestContext returns the context the passed request is verified in, the batch's one if the request has none
+func requestContext(ctx context.Context, r driver.TokenRequestToVerify) context.Context {
+	if r.Ctx != nil {
+		return r.Ctx
+	}
+
+	return ctx
+}
+
 // VerifyTokenRequest verifies a token request.
 func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequest(
 	ctx context.Context,
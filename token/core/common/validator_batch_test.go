@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LFDT-Panurus/panurus/token/driver"
+	dmock "github.com/LFDT-Panurus/panurus/token/driver/mock"
+	"github.com/LFDT-Panurus/panurus/token/driver/protos-go/v1/request"
+	"github.com/LFDT-Panurus/panurus/token/services/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChecksKey struct{}
+
+// requestKey carries a value specific to a request, such as its validation time
+type requestKey struct{}
+
+// fakeChecks records whether a postponed check has failed
+type fakeChecks struct {
+	failed bool
+}
+
+func (c *fakeChecks) Verify() error {
+	if c.failed {
+		return errors.New("deferred check failed")
+	}
+
+	return nil
+}
+
+type fakeChecksBatcher struct {
+	batches int
+}
+
+func (b *fakeChecksBatcher) WithDeferredChecks(ctx context.Context) (context.Context, DeferredChecks, error) {
+	c := &fakeChecks{}
+
+	return context.WithValue(ctx, fakeChecksKey{}, c), c, nil
+}
+
+func (b *fakeChecksBatcher) VerifyDeferredChecks(checks []DeferredChecks) error {
+	b.batches++
+	for _, c := range checks {
+		if err := c.Verify(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TestVerifyTokenRequestsFromRaw checks that the deferred checks of many requests are verified together
+// and that the failing requests are identified.
+func TestVerifyTokenRequestsFromRaw(t *testing.T) {
+	ad := &dmock.ActionDeserializer[driver.TransferAction, driver.IssueAction]{}
+	ad.DeserializeActionsReturns([]driver.IssueAction{&dmock.IssueAction{}}, nil, nil)
+
+	// the issue validator postpones a check that fails for the anchor "bad",
+	// and rejects immediately the anchor "invalid"
+	issueValidator := func(c context.Context, ctx *Context[driver.PublicParameters, driver.Input, driver.TransferAction, driver.IssueAction, driver.Deserializer]) error {
+		if ctx.Anchor == "invalid" {
+			return errors.New("invalid request")
+		}
+		if expected, ok := c.Value(requestKey{}).(string); ok && expected != string(ctx.Anchor) {
+			return errors.New("verified in the context of another request")
+		}
+		if checks, ok := c.Value(fakeChecksKey{}).(*fakeChecks); ok {
+			checks.failed = ctx.Anchor == "bad"
+
+			return nil
+		}
+		if ctx.Anchor == "bad" {
+			return errors.New("check failed")
+		}
+
+		return nil
+	}
+	v := NewValidator[driver.PublicParameters, driver.Input, driver.TransferAction, driver.IssueAction, driver.Deserializer](
+		&logging.MockLogger{},
+		&dmock.PublicParameters{},
+		&dmock.Deserializer{},
+		ad,
+		nil,
+		[]ValidateIssueFunc[driver.PublicParameters, driver.Input, driver.TransferAction, driver.IssueAction, driver.Deserializer]{issueValidator},
+		nil,
+	)
+
+	tr := &driver.TokenRequest{
+		Actions: []*driver.TypedAction{
+			{Type: request.ActionType_ACTION_TYPE_ISSUE, Raw: []byte("issue")},
+		},
+	}
+	raw, err := tr.Bytes()
+	require.NoError(t, err)
+	requests := func(anchors ...string) []driver.TokenRequestToVerify {
+		res := make([]driver.TokenRequestToVerify, len(anchors))
+		for i, a := range anchors {
+			res[i] = driver.TokenRequestToVerify{Anchor: driver.TokenRequestAnchor(a), Raw: raw}
+		}
+
+		return res
+	}
+
+	t.Run("without batcher", func(t *testing.T) {
+		results := v.VerifyTokenRequestsFromRaw(t.Context(), requests("good", "bad"))
+		require.Len(t, results, 2)
+		require.NoError(t, results[0].Err)
+		assert.Len(t, results[0].Actions, 1)
+		require.Error(t, results[1].Err)
+	})
+
+	batcher := &fakeChecksBatcher{}
+	v.ChecksBatcher = batcher
+
+	t.Run("all valid", func(t *testing.T) {
+		batcher.batches = 0
+		results := v.VerifyTokenRequestsFromRaw(t.Context(), requests("a", "b", "c"))
+		require.Len(t, results, 3)
+		for _, r := range results {
+			require.NoError(t, r.Err)
+			assert.Len(t, r.Actions, 1)
+			assert.Contains(t, r.Attributes, TokenRequestToSign)
+		}
+		assert.Equal(t, 1, batcher.batches)
+	})
+
+	t.Run("per request context", func(t *testing.T) {
+		batcher.batches = 0
+		rs := requests("a", "b")
+		for i := range rs {
+			rs[i].Ctx = context.WithValue(t.Context(), requestKey{}, string(rs[i].Anchor))
+		}
+		results := v.VerifyTokenRequestsFromRaw(context.WithValue(t.Context(), requestKey{}, "batch"), rs)
+		require.Len(t, results, 2)
+		require.NoError(t, results[0].Err)
+		require.NoError(t, results[1].Err)
+		assert.Equal(t, 1, batcher.batches)
+	})
+
+	t.Run("some invalid", func(t *testing.T) {
+		batcher.batches = 0
+		results := v.VerifyTokenRequestsFromRaw(t.Context(), requests("a", "bad", "invalid", "b"))
+		require.Len(t, results, 4)
+		require.NoError(t, results[0].Err)
+		require.Error(t, results[1].Err)
+		assert.Contains(t, results[1].Err.Error(), "deferred check failed")
+		assert.Nil(t, results[1].Actions)
+		require.Error(t, results[2].Err)
+		assert.Contains(t, results[2].Err.Error(), "invalid request")
+		require.NoError(t, results[3].Err)
+		assert.Equal(t, 1, batcher.batches)
+	})
+}
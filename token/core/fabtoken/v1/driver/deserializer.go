@@ -12,9 +12,14 @@ import (
 	"github.com/LFDT-Panurus/panurus/token/driver"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/boolpolicy"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/deserializer"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/hd"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/hybrid"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/interop/htlc"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/multisig"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/sigscheme"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/threshold"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/x509"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
 	htlc2 "github.com/LFDT-Panurus/panurus/token/services/interop/htlc"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 )
@@ -26,8 +31,21 @@ type Deserializer struct {
 
 // NewDeserializer returns a new deserializer for fabtoken.
 func NewDeserializer() *Deserializer {
+	return NewDeserializerWithChecker(nil)
+}
+
+// NewDeserializerWithChecker returns a new deserializer for fabtoken that checks
+// the certificates behind x509, Ed25519, ML-DSA and hybrid identities with the passed checker.
+func NewDeserializerWithChecker(checker *crl.Checker) *Deserializer {
 	des := deserializer.NewTypedVerifierDeserializerMultiplex()
-	des.AddTypedVerifierDeserializer(x509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&x509.IdentityDeserializer{}, &x509.AuditMatcherDeserializer{}))
+	des.AddTypedVerifierDeserializer(x509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(crl.NewDeserializer(&x509.IdentityDeserializer{}, checker, nil), &x509.AuditMatcherDeserializer{}))
+	for _, identityType := range sigscheme.IdentityTypes() {
+		des.AddTypedVerifierDeserializer(identityType, deserializer.NewTypedIdentityVerifierDeserializer(crl.NewDeserializer(sigscheme.NewIdentityDeserializer(identityType), checker, nil), &x509.AuditMatcherDeserializer{}))
+	}
+	des.AddTypedVerifierDeserializer(hybrid.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(crl.NewDeserializer(&hybrid.IdentityDeserializer{}, checker, hybrid.Certificate), &hybrid.AuditMatcherDeserializer{}))
+	// derived keys carry no certificate, hence they are not checked against the certificate revocation lists
+	des.AddTypedVerifierDeserializer(hd.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&hd.IdentityDeserializer{}, &hd.AuditMatcherDeserializer{}))
+	des.AddTypedVerifierDeserializer(threshold.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&threshold.IdentityDeserializer{}, &threshold.AuditMatcherDeserializer{}))
 	des.AddTypedVerifierDeserializer(htlc2.ScriptType, htlc.NewTypedIdentityDeserializer(des))
 	des.AddTypedVerifierDeserializer(multisig.Multisig, multisig.NewTypedIdentityDeserializer(des, des))
 	des.AddTypedVerifierDeserializer(boolpolicy.Policy, boolpolicy.NewTypedIdentityDeserializer(des, des))
@@ -50,6 +68,11 @@ type EIDRHDeserializer = deserializer.EIDRHDeserializer
 func NewEIDRHDeserializer() *EIDRHDeserializer {
 	d := deserializer.NewEIDRHDeserializer()
 	d.AddDeserializer(x509.IdentityType, &x509.AuditInfoDeserializer{})
+	d.AddDeserializer(hybrid.IdentityType, &x509.AuditInfoDeserializer{})
+	d.AddDeserializer(hd.IdentityType, &hd.AuditInfoDeserializer{})
+	for _, identityType := range sigscheme.IdentityTypes() {
+		d.AddDeserializer(identityType, &x509.AuditInfoDeserializer{})
+	}
 	d.AddDeserializer(htlc2.ScriptType, htlc.NewAuditDeserializer(d))
 	d.AddDeserializer(multisig.Multisig, &multisig.AuditInfoDeserializer{})
 	d.AddDeserializer(boolpolicy.Policy, &boolpolicy.AuditInfoDeserializer{})
@@ -15,6 +15,7 @@ import (
 	v1setup "github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/setup"
 	"github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/validator"
 	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/threshold"
 	"github.com/LFDT-Panurus/panurus/token/services/interop/htlc"
 	"github.com/LFDT-Panurus/panurus/token/services/logging"
 	"github.com/LFDT-Panurus/panurus/token/services/ttx/boolpolicy"
@@ -46,6 +47,7 @@ func NewTokenDriver(
 	endpointService cdriver.NetworkBinderService,
 	networkProvider cdriver.NetworkProvider,
 	vaultProvider cdriver.VaultProvider,
+	coordinators *threshold.Coordinators,
 ) core.NamedFactory[driver.Driver] {
 	return core.NamedFactory[driver.Driver]{
 		Name: core.DriverIdentifier(v1setup.FabTokenDriverName, 1),
@@ -58,6 +60,7 @@ func NewTokenDriver(
 			endpointService,
 			networkProvider,
 			vaultProvider,
+			coordinators,
 		),
 	}
 }
@@ -71,16 +74,18 @@ func newTokenDriver(
 	endpointService cdriver.NetworkBinderService,
 	networkProvider cdriver.NetworkProvider,
 	vaultProvider cdriver.VaultProvider,
+	coordinators *threshold.Coordinators,
 ) *Driver {
 	return &Driver{
-		metricsProvider:  metricsProvider,
-		tracerProvider:   tracerProvider,
-		configService:    configService,
-		storageProvider:  storageProvider,
-		identityProvider: identityProvider,
-		endpointService:  endpointService,
-		networkProvider:  networkProvider,
-		vaultProvider:    vaultProvider,
+		BaseWalletServiceFactory: BaseWalletServiceFactory{Coordinators: coordinators},
+		metricsProvider:          metricsProvider,
+		tracerProvider:           tracerProvider,
+		configService:            configService,
+		storageProvider:          storageProvider,
+		identityProvider:         identityProvider,
+		endpointService:          endpointService,
+		networkProvider:          networkProvider,
+		vaultProvider:            vaultProvider,
 	}
 }
 
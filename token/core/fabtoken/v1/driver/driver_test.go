@@ -7,8 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package driver_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/LFDT-Panurus/panurus/token/core"
 	mock2 "github.com/LFDT-Panurus/panurus/token/core/common/driver/mock"
@@ -16,8 +24,12 @@ import (
 	"github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/setup"
 	tdriver "github.com/LFDT-Panurus/panurus/token/driver"
 	dmock "github.com/LFDT-Panurus/panurus/token/driver/mock"
+	"github.com/LFDT-Panurus/panurus/token/services/identity"
 	imock "github.com/LFDT-Panurus/panurus/token/services/identity/driver/mock"
 	idmock "github.com/LFDT-Panurus/panurus/token/services/identity/mock"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/threshold"
+	x5092 "github.com/LFDT-Panurus/panurus/token/services/identity/x509"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
 	"github.com/LFDT-Panurus/panurus/token/services/network"
 	"github.com/LFDT-Panurus/panurus/token/services/storage"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/metrics/disabled"
@@ -45,6 +57,7 @@ func TestNewDriver(t *testing.T) {
 		endpointService,
 		networkProvider,
 		vaultProvider,
+		threshold.NewCoordinators(),
 	)
 
 	assert.NotNil(t, factory.Driver)
@@ -70,6 +83,7 @@ func TestNewTokenService(t *testing.T) {
 		endpointService,
 		networkProvider,
 		vaultProvider,
+		threshold.NewCoordinators(),
 	).Driver.(*driver.Driver)
 
 	tmsID := tdriver.TMSID{Network: "n1", Channel: "c1", Namespace: "ns1"}
@@ -171,6 +185,13 @@ func TestNewDefaultValidator(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, v)
 	assert.Contains(t, err.Error(), "invalid public parameters type")
+
+	// Case 3: Invalid certificate revocation lists
+	pp.ExtraData = tdriver.Extras{crl.CRLsExtrasKey: []byte("garbage")}
+	v, err = d.NewValidator(pp)
+	require.Error(t, err)
+	assert.Nil(t, v)
+	assert.Contains(t, err.Error(), "failed to load certificate revocation lists")
 }
 
 // TestPublicParametersFromBytes tests the unmarshalling of public parameters from bytes.
@@ -275,3 +296,49 @@ func TestDeserializers(t *testing.T) {
 	eidrh := driver.NewEIDRHDeserializer()
 	assert.NotNil(t, eidrh)
 }
+
+// TestDeserializerWithChecker tests that revoked certificates are rejected.
+func TestDeserializerWithChecker(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err = x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "issuer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, ca, key.Public(), caKey)
+	require.NoError(t, err)
+	issuer, err := identity.WrapWithType(x5092.IdentityType, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	require.NoError(t, err)
+
+	_, err = driver.NewDeserializer().GetIssuerVerifier(t.Context(), issuer)
+	require.NoError(t, err)
+
+	der, err = x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now(),
+		NextUpdate:                time.Now().Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{{SerialNumber: big.NewInt(2), RevocationTime: time.Now()}},
+	}, ca, caKey)
+	require.NoError(t, err)
+	list, err := x509.ParseRevocationList(der)
+	require.NoError(t, err)
+	d := driver.NewDeserializerWithChecker(crl.NewChecker(crl.StaticSource{list}, []*x509.Certificate{ca}, crl.Policy{}))
+	_, err = d.GetIssuerVerifier(t.Context(), issuer)
+	require.ErrorContains(t, err, "has been revoked")
+}
@@ -11,6 +11,7 @@ import (
 	v1 "github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/setup"
 	"github.com/LFDT-Panurus/panurus/token/core/fabtoken/v1/validator"
 	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
 	"github.com/LFDT-Panurus/panurus/token/services/logging"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 )
@@ -37,7 +38,11 @@ func (d ValidatorDriver) NewValidator(pp driver.PublicParameters) (driver.Valida
 		return nil, errors.Errorf("invalid public parameters type [%T]", pp)
 	}
 	logger := logging.DriverLoggerFromPP("panurus.driver.fabtoken", string(core.DriverIdentifierFromPP(pp)))
-	deserializer := NewDeserializer()
+	checker, err := crl.NewCheckerFromExtras(ppp.Extras())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to load certificate revocation lists")
+	}
+	deserializer := NewDeserializerWithChecker(checker)
 
 	return validator.NewValidator(logger, ppp, deserializer, nil, nil, nil), nil
 }
@@ -13,10 +13,15 @@ import (
 	"github.com/LFDT-Panurus/panurus/token/services/identity"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/config"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/deserializer"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/hd"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/hybrid"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/membership"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/role"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/sigscheme"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/threshold"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/wallet"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/x509"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
 	"github.com/LFDT-Panurus/panurus/token/services/logging"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/metrics/disabled"
@@ -24,6 +29,8 @@ import (
 
 type BaseWalletServiceFactory struct {
 	PublicParametersDeserializer
+	// Coordinators are the coordinators of the signing ceremonies of threshold identities
+	Coordinators *threshold.Coordinators
 }
 
 // newWalletService returns a new wallet service for the passed configuration and parameters.
@@ -50,6 +57,10 @@ func (d BaseWalletServiceFactory) newWalletService(
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to open keystore for tms [%s]", tmsID)
 	}
+	walletDB, err := storageProvider.WalletStore(tmsID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get identity storage provider")
+	}
 	identityProvider := identity.NewProvider(logger.Named("identity"), identityDB, deserializerManager, binder, NewEIDRHDeserializer())
 	identityConfig, err := config.NewIdentityConfig(tmsConfig)
 	if err != nil {
@@ -58,6 +69,8 @@ func (d BaseWalletServiceFactory) newWalletService(
 
 	// Prepare roles
 	keyStore := x509.NewKeyStore(baseKeyStore)
+	// Ed25519 and ML-DSA identities go first, the x509 key manager would load them as verify-only identities
+	sigschemeKMP := sigscheme.NewKeyManagerProvider(identityConfig)
 	roleFactory := membership.NewRoleFactory(
 		logger,
 		tmsID,
@@ -68,18 +81,24 @@ func (d BaseWalletServiceFactory) newWalletService(
 		storageProvider,
 		deserializerManager,
 	)
-	newRole, err := roleFactory.NewRole(identity.OwnerRole, false, nil, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
+	// owners can also derive a fresh x509 key for every transfer, see the hd package
+	hdKMP := hd.NewKeyManagerProvider(identityConfig, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote), baseKeyStore, walletDB)
+	newRole, err := roleFactory.NewRole(identity.OwnerRole, false, nil, hdKMP, sigschemeKMP, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to create owner role")
 	}
 	roles := role.NewRoles()
 	roles.Register(identity.OwnerRole, newRole)
-	newRole, err = roleFactory.NewRole(identity.IssuerRole, false, pp.Issuers(), x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
+	// issuers and auditors can also be hybrid identities, pairing an x509 identity with an ML-DSA key,
+	// or threshold identities whose shares are distributed among several nodes
+	hybridKMP := hybrid.NewKeyManagerProvider(identityConfig, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
+	thresholdKMP := threshold.NewKeyManagerProvider(threshold.NewStore(baseKeyStore), d.Coordinators)
+	newRole, err = roleFactory.NewRole(identity.IssuerRole, false, pp.Issuers(), hybridKMP, sigschemeKMP, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote), thresholdKMP)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to create issuer role")
 	}
 	roles.Register(identity.IssuerRole, newRole)
-	newRole, err = roleFactory.NewRole(identity.AuditorRole, false, pp.Auditors(), x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
+	newRole, err = roleFactory.NewRole(identity.AuditorRole, false, pp.Auditors(), hybridKMP, sigschemeKMP, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote), thresholdKMP)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to create auditor role")
 	}
@@ -91,11 +110,11 @@ func (d BaseWalletServiceFactory) newWalletService(
 	roles.Register(identity.CertifierRole, newRole)
 
 	// Instantiate the wallet service
-	walletDB, err := storageProvider.WalletStore(tmsID)
+	checker, err := crl.NewCheckerFromExtras(pp.Extras())
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get identity storage provider")
+		return nil, errors.WithMessagef(err, "failed to load certificate revocation lists")
 	}
-	deserializer := NewDeserializer()
+	deserializer := NewDeserializerWithChecker(checker)
 	ws := wallet.NewService(
 		logger,
 		identityProvider,
@@ -116,8 +135,12 @@ type WalletServiceFactory struct {
 // NewWalletServiceFactory returns a new factory for fabtoken wallet services.
 func NewWalletServiceFactory(storageProvider identity.StorageProvider) core.NamedFactory[driver.WalletServiceFactory] {
 	return core.NamedFactory[driver.WalletServiceFactory]{
-		Name:   core.DriverIdentifier(v2.FabTokenDriverName, v2.ProtocolV1),
-		Driver: &WalletServiceFactory{storageProvider: storageProvider},
+		Name: core.DriverIdentifier(v2.FabTokenDriverName, v2.ProtocolV1),
+		Driver: &WalletServiceFactory{
+			// wallet services built by this factory do not sign, no coordinator is ever registered
+			BaseWalletServiceFactory: BaseWalletServiceFactory{Coordinators: threshold.NewCoordinators()},
+			storageProvider:          storageProvider,
+		},
 	}
 }
 
@@ -172,19 +172,57 @@ func (m *TMSProvider) Update(opts driver.ServiceOptions) (err error) {
 	}
 
 	// create the service for the new public params
+	return m.replace(key, service, opts)
+}
+
+// Reload rebuilds the cached service for the specified TMS with its current public parameters,
+// so that the new service picks up the changes of the TMS configuration.
+// If no service is cached for the TMS, nothing happens.
+func (m *TMSProvider) Reload(opts driver.ServiceOptions) error {
+	if len(opts.Network) == 0 {
+		return errors.Errorf("network not specified")
+	}
+	if len(opts.Namespace) == 0 {
+		return errors.Errorf("namespace not specified")
+	}
+
+	key := tmsKey(opts)
+	logger.Debugf("reload tms for [%s] with key [%s]", opts, key)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	service, ok := m.services[key]
+	if !ok {
+		logger.Debugf("no service found for [%s:%s:%s], nothing to reload", opts.Network, opts.Channel, opts.Namespace)
+
+		return nil
+	}
+	ppRaw, err := service.PublicParamsManager().PublicParameters().Serialize()
+	if err != nil {
+		return errors.WithMessagef(err, "failed to serialize the public params of [%s]", opts)
+	}
+	opts.PublicParams = ppRaw
+
+	return m.replace(key, service, opts)
+}
+
+// replace creates a new service for the passed options and registers it in place of the passed one, if any.
+// It must be called while holding the lock.
+func (m *TMSProvider) replace(key string, service driver.TokenManagerService, opts driver.ServiceOptions) error {
 	newService, err := m.getTokenManagerService(opts)
-	if err == nil {
-		// unload the old service, if set
-		if service != nil {
-			if err := service.Done(); err != nil {
-				return errors.WithMessagef(err, "failed to unload token service")
-			}
+	if err != nil {
+		return err
+	}
+	// unload the old service, if set
+	if service != nil {
+		if err := service.Done(); err != nil {
+			return errors.WithMessagef(err, "failed to unload token service")
 		}
-		// register the new service
-		m.services[key] = newService
 	}
+	// register the new service
+	m.services[key] = newService
 
-	return err
+	return nil
 }
 
 // SetCallback sets the callback function to be invoked when a new TMS is created.
@@ -141,6 +141,30 @@ func TestTMSProvider(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	// Test case: Reload rebuilds the cached service with its current public parameters.
+	t.Run("Reload", func(t *testing.T) {
+		currentPP := &drivermock.PublicParameters{}
+		currentPP.SerializeReturns(ppJSON, nil)
+		ppm := &drivermock.PublicParamsManager{}
+		ppm.PublicParametersReturns(currentPP)
+		expectedTMS.PublicParamsManagerReturns(ppm)
+
+		doneCalls := expectedTMS.DoneCallCount()
+		newCalls := driverMock.NewTokenServiceCallCount()
+		require.NoError(t, provider.Reload(driver.ServiceOptions{Network: "n1", Channel: "c1", Namespace: "ns1"}))
+		assert.Equal(t, doneCalls+1, expectedTMS.DoneCallCount())
+		assert.Equal(t, newCalls+1, driverMock.NewTokenServiceCallCount())
+		_, raw := driverMock.NewTokenServiceArgsForCall(newCalls)
+		assert.Equal(t, ppJSON, raw)
+
+		// nothing to reload if the service is not cached
+		require.NoError(t, provider.Reload(driver.ServiceOptions{Network: "unknown", Namespace: "ns"}))
+		assert.Equal(t, newCalls+1, driverMock.NewTokenServiceCallCount())
+
+		require.Error(t, provider.Reload(driver.ServiceOptions{}))
+		require.Error(t, provider.Reload(driver.ServiceOptions{Network: "n"}))
+	})
+
 	// Test case: SetCallback verifies that the callback is invoked when a new TMS is created.
 	t.Run("SetCallback", func(t *testing.T) {
 		callbackCalled := false
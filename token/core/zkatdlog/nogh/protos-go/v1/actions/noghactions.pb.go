@@ -326,7 +326,7 @@ func (x *TransferActionOutput) GetToken() *Token {
 }
 
 // Proof contains a zero-knowledge proof demonstrating the validity of an action.
-// It can use either the standard proof system or the CSP-based proof system.
+// It can use the standard proof system, the CSP-based proof system, or their aggregated variants.
 type Proof struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// proof_type determines which proof system is used
@@ -335,6 +335,8 @@ type Proof struct {
 	//
 	//	*Proof_Proof
 	//	*Proof_CspBasedProof
+	//	*Proof_AggregatedProof
+	//	*Proof_AggregatedCspBasedProof
 	ProofType     isProof_ProofType `protobuf_oneof:"proof_type"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -395,6 +397,24 @@ func (x *Proof) GetCspBasedProof() []byte {
 	return nil
 }
 
+func (x *Proof) GetAggregatedProof() []byte {
+	if x != nil {
+		if x, ok := x.ProofType.(*Proof_AggregatedProof); ok {
+			return x.AggregatedProof
+		}
+	}
+	return nil
+}
+
+func (x *Proof) GetAggregatedCspBasedProof() []byte {
+	if x != nil {
+		if x, ok := x.ProofType.(*Proof_AggregatedCspBasedProof); ok {
+			return x.AggregatedCspBasedProof
+		}
+	}
+	return nil
+}
+
 type isProof_ProofType interface {
 	isProof_ProofType()
 }
@@ -409,10 +429,24 @@ type Proof_CspBasedProof struct {
 	CspBasedProof []byte `protobuf:"bytes,2,opt,name=csp_based_proof,json=cspBasedProof,proto3,oneof"`
 }
 
+type Proof_AggregatedProof struct {
+	// aggregated_proof is the zero-knowledge proof whose range proofs are aggregated across the outputs
+	AggregatedProof []byte `protobuf:"bytes,3,opt,name=aggregated_proof,json=aggregatedProof,proto3,oneof"`
+}
+
+type Proof_AggregatedCspBasedProof struct {
+	// aggregated_csp_based_proof is the CSP-based proof whose range proofs are aggregated across the outputs
+	AggregatedCspBasedProof []byte `protobuf:"bytes,4,opt,name=aggregated_csp_based_proof,json=aggregatedCspBasedProof,proto3,oneof"`
+}
+
 func (*Proof_Proof) isProof_ProofType() {}
 
 func (*Proof_CspBasedProof) isProof_ProofType() {}
 
+func (*Proof_AggregatedProof) isProof_ProofType() {}
+
+func (*Proof_AggregatedCspBasedProof) isProof_ProofType() {}
+
 // TransferAction represents a privacy-preserving token transfer that spends existing
 // tokens and creates new tokens while proving correctness without revealing amounts.
 type TransferAction struct {
@@ -719,10 +753,12 @@ const file_noghactions_proto_rawDesc = "" +
 	"\x06output\x18\x01 \x01(\v2).fabric_token_sdk.token.fabtoken.v1.TokenR\x06output\x12O\n" +
 	"\x0fblinding_factor\x18\x02 \x01(\v2&.fabric_token_sdk.token.zkatdlog.v1.ZrR\x0eblindingFactor\"W\n" +
 	"\x14TransferActionOutput\x12?\n" +
-	"\x05token\x18\x01 \x01(\v2).fabric_token_sdk.token.zkatdlog.v1.TokenR\x05token\"W\n" +
+	"\x05token\x18\x01 \x01(\v2).fabric_token_sdk.token.zkatdlog.v1.TokenR\x05token\"\xc3\x01\n" +
 	"\x05Proof\x12\x16\n" +
 	"\x05proof\x18\x01 \x01(\fH\x00R\x05proof\x12(\n" +
-	"\x0fcsp_based_proof\x18\x02 \x01(\fH\x00R\rcspBasedProofB\f\n" +
+	"\x0fcsp_based_proof\x18\x02 \x01(\fH\x00R\rcspBasedProof\x12+\n" +
+	"\x10aggregated_proof\x18\x03 \x01(\fH\x00R\x0faggregatedProof\x12=\n" +
+	"\x1aaggregated_csp_based_proof\x18\x04 \x01(\fH\x00R\x17aggregatedCspBasedProofB\f\n" +
 	"\n" +
 	"proof_type\"\xef\x03\n" +
 	"\x0eTransferAction\x12\x18\n" +
@@ -821,6 +857,8 @@ func file_noghactions_proto_init() {
 	file_noghactions_proto_msgTypes[5].OneofWrappers = []any{
 		(*Proof_Proof)(nil),
 		(*Proof_CspBasedProof)(nil),
+		(*Proof_AggregatedProof)(nil),
+		(*Proof_AggregatedCspBasedProof)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -250,6 +250,178 @@ func (x *CSPRangeProofParams) GetNumberOfRounds() uint64 {
 	return 0
 }
 
+// AggregatedRangeProofParams contains the public parameters for the aggregated Bulletproofs range proof system.
+// An aggregated range proof shows that up to max_aggregation committed values lie within the range
+// with a single proof whose size is logarithmic in the number of values.
+type AggregatedRangeProofParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// left_generators are the G vector generators for the inner product argument,
+	// bit_length generators for each aggregated value
+	LeftGenerators []*math.G1 `protobuf:"bytes,1,rep,name=left_generators,json=leftGenerators,proto3" json:"left_generators,omitempty"`
+	// right_generators are the H vector generators for the inner product argument,
+	// bit_length generators for each aggregated value
+	RightGenerators []*math.G1 `protobuf:"bytes,2,rep,name=right_generators,json=rightGenerators,proto3" json:"right_generators,omitempty"`
+	// p is a generator used in the range proof protocol
+	P *math.G1 `protobuf:"bytes,3,opt,name=p,proto3" json:"p,omitempty"`
+	// q is another generator used in the range proof protocol
+	Q *math.G1 `protobuf:"bytes,4,opt,name=q,proto3" json:"q,omitempty"`
+	// bit_length is the number of bits in the range (e.g., 64 for 64-bit values)
+	BitLength uint64 `protobuf:"varint,5,opt,name=bit_length,json=bitLength,proto3" json:"bit_length,omitempty"`
+	// max_aggregation is the maximum number of values proven by a single aggregated proof, a power of two
+	MaxAggregation uint64 `protobuf:"varint,6,opt,name=max_aggregation,json=maxAggregation,proto3" json:"max_aggregation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AggregatedRangeProofParams) Reset() {
+	*x = AggregatedRangeProofParams{}
+	mi := &file_noghpp_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregatedRangeProofParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregatedRangeProofParams) ProtoMessage() {}
+
+func (x *AggregatedRangeProofParams) ProtoReflect() protoreflect.Message {
+	mi := &file_noghpp_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregatedRangeProofParams.ProtoReflect.Descriptor instead.
+func (*AggregatedRangeProofParams) Descriptor() ([]byte, []int) {
+	return file_noghpp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AggregatedRangeProofParams) GetLeftGenerators() []*math.G1 {
+	if x != nil {
+		return x.LeftGenerators
+	}
+	return nil
+}
+
+func (x *AggregatedRangeProofParams) GetRightGenerators() []*math.G1 {
+	if x != nil {
+		return x.RightGenerators
+	}
+	return nil
+}
+
+func (x *AggregatedRangeProofParams) GetP() *math.G1 {
+	if x != nil {
+		return x.P
+	}
+	return nil
+}
+
+func (x *AggregatedRangeProofParams) GetQ() *math.G1 {
+	if x != nil {
+		return x.Q
+	}
+	return nil
+}
+
+func (x *AggregatedRangeProofParams) GetBitLength() uint64 {
+	if x != nil {
+		return x.BitLength
+	}
+	return 0
+}
+
+func (x *AggregatedRangeProofParams) GetMaxAggregation() uint64 {
+	if x != nil {
+		return x.MaxAggregation
+	}
+	return 0
+}
+
+// AggregatedCSPRangeProofParams contains the public parameters for the aggregated CSP-based range proof system.
+// An aggregated CSP range proof shows that up to max_aggregation committed values lie within the range
+// with a single proof whose size is logarithmic in the number of values.
+type AggregatedCSPRangeProofParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// left_generators are the G vector generators for the CSP proof,
+	// bit_length generators for each aggregated value plus one
+	LeftGenerators []*math.G1 `protobuf:"bytes,1,rep,name=left_generators,json=leftGenerators,proto3" json:"left_generators,omitempty"`
+	// right_generators are the H vector generators for the CSP proof,
+	// bit_length generators for each aggregated value plus one
+	RightGenerators []*math.G1 `protobuf:"bytes,2,rep,name=right_generators,json=rightGenerators,proto3" json:"right_generators,omitempty"`
+	// bit_length is the number of bits in the range (e.g., 64 for 64-bit values)
+	BitLength uint64 `protobuf:"varint,3,opt,name=bit_length,json=bitLength,proto3" json:"bit_length,omitempty"`
+	// max_aggregation is the maximum number of values proven by a single aggregated proof
+	MaxAggregation uint64 `protobuf:"varint,4,opt,name=max_aggregation,json=maxAggregation,proto3" json:"max_aggregation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AggregatedCSPRangeProofParams) Reset() {
+	*x = AggregatedCSPRangeProofParams{}
+	mi := &file_noghpp_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregatedCSPRangeProofParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregatedCSPRangeProofParams) ProtoMessage() {}
+
+func (x *AggregatedCSPRangeProofParams) ProtoReflect() protoreflect.Message {
+	mi := &file_noghpp_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregatedCSPRangeProofParams.ProtoReflect.Descriptor instead.
+func (*AggregatedCSPRangeProofParams) Descriptor() ([]byte, []int) {
+	return file_noghpp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AggregatedCSPRangeProofParams) GetLeftGenerators() []*math.G1 {
+	if x != nil {
+		return x.LeftGenerators
+	}
+	return nil
+}
+
+func (x *AggregatedCSPRangeProofParams) GetRightGenerators() []*math.G1 {
+	if x != nil {
+		return x.RightGenerators
+	}
+	return nil
+}
+
+func (x *AggregatedCSPRangeProofParams) GetBitLength() uint64 {
+	if x != nil {
+		return x.BitLength
+	}
+	return 0
+}
+
+func (x *AggregatedCSPRangeProofParams) GetMaxAggregation() uint64 {
+	if x != nil {
+		return x.MaxAggregation
+	}
+	return 0
+}
+
 // PublicParameters contains the public configuration for the zkatdlog (zero-knowledge
 // anonymous token with discrete log) driver. These parameters define the cryptographic
 // setup for privacy-preserving token operations.
@@ -281,13 +453,17 @@ type PublicParameters struct {
 	Metadata map[string][]byte `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// csp_range_proof_params contains parameters for the CSP-based range proof system
 	CspRangeProofParams *CSPRangeProofParams `protobuf:"bytes,12,opt,name=csp_range_proof_params,json=cspRangeProofParams,proto3" json:"csp_range_proof_params,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// aggregated_range_proof_params contains parameters for the aggregated Bulletproofs range proof system
+	AggregatedRangeProofParams *AggregatedRangeProofParams `protobuf:"bytes,13,opt,name=aggregated_range_proof_params,json=aggregatedRangeProofParams,proto3" json:"aggregated_range_proof_params,omitempty"`
+	// aggregated_csp_range_proof_params contains parameters for the aggregated CSP-based range proof system
+	AggregatedCspRangeProofParams *AggregatedCSPRangeProofParams `protobuf:"bytes,14,opt,name=aggregated_csp_range_proof_params,json=aggregatedCspRangeProofParams,proto3" json:"aggregated_csp_range_proof_params,omitempty"`
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
 }
 
 func (x *PublicParameters) Reset() {
 	*x = PublicParameters{}
-	mi := &file_noghpp_proto_msgTypes[3]
+	mi := &file_noghpp_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -299,7 +475,7 @@ func (x *PublicParameters) String() string {
 func (*PublicParameters) ProtoMessage() {}
 
 func (x *PublicParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_noghpp_proto_msgTypes[3]
+	mi := &file_noghpp_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,7 +488,7 @@ func (x *PublicParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicParameters.ProtoReflect.Descriptor instead.
 func (*PublicParameters) Descriptor() ([]byte, []int) {
-	return file_noghpp_proto_rawDescGZIP(), []int{3}
+	return file_noghpp_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PublicParameters) GetTokenDriverName() string {
@@ -399,6 +575,20 @@ func (x *PublicParameters) GetCspRangeProofParams() *CSPRangeProofParams {
 	return nil
 }
 
+func (x *PublicParameters) GetAggregatedRangeProofParams() *AggregatedRangeProofParams {
+	if x != nil {
+		return x.AggregatedRangeProofParams
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetAggregatedCspRangeProofParams() *AggregatedCSPRangeProofParams {
+	if x != nil {
+		return x.AggregatedCspRangeProofParams
+	}
+	return nil
+}
+
 var File_noghpp_proto protoreflect.FileDescriptor
 
 const file_noghpp_proto_rawDesc = "" +
@@ -421,7 +611,21 @@ const file_noghpp_proto_rawDesc = "" +
 	"\x10right_generators\x18\x02 \x03(\v2&.fabric_token_sdk.token.zkatdlog.v1.G1R\x0frightGenerators\x12\x1d\n" +
 	"\n" +
 	"bit_length\x18\x03 \x01(\x04R\tbitLength\x12(\n" +
-	"\x10number_of_rounds\x18\x04 \x01(\x04R\x0enumberOfRounds\"\xd0\a\n" +
+	"\x10number_of_rounds\x18\x04 \x01(\x04R\x0enumberOfRounds\"\xf4\x02\n" +
+	"\x1aAggregatedRangeProofParams\x12O\n" +
+	"\x0fleft_generators\x18\x01 \x03(\v2&.fabric_token_sdk.token.zkatdlog.v1.G1R\x0eleftGenerators\x12Q\n" +
+	"\x10right_generators\x18\x02 \x03(\v2&.fabric_token_sdk.token.zkatdlog.v1.G1R\x0frightGenerators\x124\n" +
+	"\x01p\x18\x03 \x01(\v2&.fabric_token_sdk.token.zkatdlog.v1.G1R\x01p\x124\n" +
+	"\x01q\x18\x04 \x01(\v2&.fabric_token_sdk.token.zkatdlog.v1.G1R\x01q\x12\x1d\n" +
+	"\n" +
+	"bit_length\x18\x05 \x01(\x04R\tbitLength\x12'\n" +
+	"\x0fmax_aggregation\x18\x06 \x01(\x04R\x0emaxAggregation\"\x8b\x02\n" +
+	"\x1dAggregatedCSPRangeProofParams\x12O\n" +
+	"\x0fleft_generators\x18\x01 \x03(\v2&.fabric_token_sdk.token.zkatdlog.v1.G1R\x0eleftGenerators\x12Q\n" +
+	"\x10right_generators\x18\x02 \x03(\v2&.fabric_token_sdk.token.zkatdlog.v1.G1R\x0frightGenerators\x12\x1d\n" +
+	"\n" +
+	"bit_length\x18\x03 \x01(\x04R\tbitLength\x12'\n" +
+	"\x0fmax_aggregation\x18\x04 \x01(\x04R\x0emaxAggregation\"\xe2\t\n" +
 	"\x10PublicParameters\x12*\n" +
 	"\x11token_driver_name\x18\x01 \x01(\tR\x0ftokenDriverName\x120\n" +
 	"\x14token_driver_version\x18\x02 \x01(\rR\x12tokenDriverVersion\x12F\n" +
@@ -435,7 +639,9 @@ const file_noghpp_proto_rawDesc = "" +
 	"\x12quantity_precision\x18\n" +
 	" \x01(\x04R\x11quantityPrecision\x12^\n" +
 	"\bmetadata\x18\v \x03(\v2B.fabric_token_sdk.token.zkatdlog.v1.PublicParameters.MetadataEntryR\bmetadata\x12l\n" +
-	"\x16csp_range_proof_params\x18\f \x01(\v27.fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParamsR\x13cspRangeProofParams\x1a;\n" +
+	"\x16csp_range_proof_params\x18\f \x01(\v27.fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParamsR\x13cspRangeProofParams\x12\x81\x01\n" +
+	"\x1daggregated_range_proof_params\x18\r \x01(\v2>.fabric_token_sdk.token.zkatdlog.v1.AggregatedRangeProofParamsR\x1aaggregatedRangeProofParams\x12\x8b\x01\n" +
+	"!aggregated_csp_range_proof_params\x18\x0e \x01(\v2A.fabric_token_sdk.token.zkatdlog.v1.AggregatedCSPRangeProofParamsR\x1daggregatedCspRangeProofParams\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01BJZHgithub.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/protos-go/v1/ppb\x06proto3"
@@ -452,38 +658,48 @@ func file_noghpp_proto_rawDescGZIP() []byte {
 	return file_noghpp_proto_rawDescData
 }
 
-var file_noghpp_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_noghpp_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_noghpp_proto_goTypes = []any{
-	(*IdemixIssuerPublicKey)(nil), // 0: fabric_token_sdk.token.zkatdlog.v1.IdemixIssuerPublicKey
-	(*RangeProofParams)(nil),      // 1: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams
-	(*CSPRangeProofParams)(nil),   // 2: fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParams
-	(*PublicParameters)(nil),      // 3: fabric_token_sdk.token.zkatdlog.v1.PublicParameters
-	nil,                           // 4: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.MetadataEntry
-	(*math.CurveID)(nil),          // 5: fabric_token_sdk.token.zkatdlog.v1.CurveID
-	(*math.G1)(nil),               // 6: fabric_token_sdk.token.zkatdlog.v1.G1
-	(*v1.Identity)(nil),           // 7: fabric_token_sdk.token.driver.v1.Identity
+	(*IdemixIssuerPublicKey)(nil),         // 0: fabric_token_sdk.token.zkatdlog.v1.IdemixIssuerPublicKey
+	(*RangeProofParams)(nil),              // 1: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams
+	(*CSPRangeProofParams)(nil),           // 2: fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParams
+	(*AggregatedRangeProofParams)(nil),    // 3: fabric_token_sdk.token.zkatdlog.v1.AggregatedRangeProofParams
+	(*AggregatedCSPRangeProofParams)(nil), // 4: fabric_token_sdk.token.zkatdlog.v1.AggregatedCSPRangeProofParams
+	(*PublicParameters)(nil),              // 5: fabric_token_sdk.token.zkatdlog.v1.PublicParameters
+	nil,                                   // 6: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.MetadataEntry
+	(*math.CurveID)(nil),                  // 7: fabric_token_sdk.token.zkatdlog.v1.CurveID
+	(*math.G1)(nil),                       // 8: fabric_token_sdk.token.zkatdlog.v1.G1
+	(*v1.Identity)(nil),                   // 9: fabric_token_sdk.token.driver.v1.Identity
 }
 var file_noghpp_proto_depIdxs = []int32{
-	5,  // 0: fabric_token_sdk.token.zkatdlog.v1.IdemixIssuerPublicKey.curve_id:type_name -> fabric_token_sdk.token.zkatdlog.v1.CurveID
-	6,  // 1: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams.left_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
-	6,  // 2: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams.right_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
-	6,  // 3: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams.p:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
-	6,  // 4: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams.q:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
-	6,  // 5: fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParams.left_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
-	6,  // 6: fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParams.right_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
-	5,  // 7: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.curve_id:type_name -> fabric_token_sdk.token.zkatdlog.v1.CurveID
-	6,  // 8: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.pedersen_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
-	1,  // 9: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.range_proof_params:type_name -> fabric_token_sdk.token.zkatdlog.v1.RangeProofParams
-	0,  // 10: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.idemix_issuer_public_keys:type_name -> fabric_token_sdk.token.zkatdlog.v1.IdemixIssuerPublicKey
-	7,  // 11: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.auditors:type_name -> fabric_token_sdk.token.driver.v1.Identity
-	7,  // 12: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.issuers:type_name -> fabric_token_sdk.token.driver.v1.Identity
-	4,  // 13: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.metadata:type_name -> fabric_token_sdk.token.zkatdlog.v1.PublicParameters.MetadataEntry
-	2,  // 14: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.csp_range_proof_params:type_name -> fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParams
-	15, // [15:15] is the sub-list for method output_type
-	15, // [15:15] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	7,  // 0: fabric_token_sdk.token.zkatdlog.v1.IdemixIssuerPublicKey.curve_id:type_name -> fabric_token_sdk.token.zkatdlog.v1.CurveID
+	8,  // 1: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams.left_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 2: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams.right_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 3: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams.p:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 4: fabric_token_sdk.token.zkatdlog.v1.RangeProofParams.q:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 5: fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParams.left_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 6: fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParams.right_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 7: fabric_token_sdk.token.zkatdlog.v1.AggregatedRangeProofParams.left_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 8: fabric_token_sdk.token.zkatdlog.v1.AggregatedRangeProofParams.right_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 9: fabric_token_sdk.token.zkatdlog.v1.AggregatedRangeProofParams.p:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 10: fabric_token_sdk.token.zkatdlog.v1.AggregatedRangeProofParams.q:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 11: fabric_token_sdk.token.zkatdlog.v1.AggregatedCSPRangeProofParams.left_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	8,  // 12: fabric_token_sdk.token.zkatdlog.v1.AggregatedCSPRangeProofParams.right_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	7,  // 13: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.curve_id:type_name -> fabric_token_sdk.token.zkatdlog.v1.CurveID
+	8,  // 14: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.pedersen_generators:type_name -> fabric_token_sdk.token.zkatdlog.v1.G1
+	1,  // 15: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.range_proof_params:type_name -> fabric_token_sdk.token.zkatdlog.v1.RangeProofParams
+	0,  // 16: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.idemix_issuer_public_keys:type_name -> fabric_token_sdk.token.zkatdlog.v1.IdemixIssuerPublicKey
+	9,  // 17: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.auditors:type_name -> fabric_token_sdk.token.driver.v1.Identity
+	9,  // 18: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.issuers:type_name -> fabric_token_sdk.token.driver.v1.Identity
+	6,  // 19: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.metadata:type_name -> fabric_token_sdk.token.zkatdlog.v1.PublicParameters.MetadataEntry
+	2,  // 20: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.csp_range_proof_params:type_name -> fabric_token_sdk.token.zkatdlog.v1.CSPRangeProofParams
+	3,  // 21: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.aggregated_range_proof_params:type_name -> fabric_token_sdk.token.zkatdlog.v1.AggregatedRangeProofParams
+	4,  // 22: fabric_token_sdk.token.zkatdlog.v1.PublicParameters.aggregated_csp_range_proof_params:type_name -> fabric_token_sdk.token.zkatdlog.v1.AggregatedCSPRangeProofParams
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_noghpp_proto_init() }
@@ -497,7 +713,7 @@ func file_noghpp_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_noghpp_proto_rawDesc), len(file_noghpp_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -16,11 +16,11 @@ import (
 )
 
 var (
-	proofType    = flag.String("proof_type", "1", "1 or bulletproof or bf, 2 or csp")
+	proofType    = flag.String("proof_type", "1", "1 or bulletproof or bf, 2 or csp, 3 or aggregated or agg, 4 or aggregated_csp or aggcsp")
 	executorFlag = flag.String("executor", "serial", "execution strategy for range proofs: serial, unbounded, pool")
 )
 
-// ProofType returns the proof type flag value (0 = RangeProof, 1 = CSPRangeProof).
+// ProofType returns the proof type flag value (0 = RangeProof, 1 = CSPRangeProof, 2 = AggregatedRangeProof, 3 = AggregatedCSPRangeProof).
 func ProofType() rp.ProofType {
 	str := *proofType
 	if len(str) == 0 {
@@ -36,6 +36,14 @@ func ProofType() rp.ProofType {
 		return rp.CSPRangeProofType
 	case "csp":
 		return rp.CSPRangeProofType
+	case "3":
+		return rp.AggregatedRangeProofType
+	case "agg", "aggregated":
+		return rp.AggregatedRangeProofType
+	case "4":
+		return rp.AggregatedCSPRangeProofType
+	case "aggcsp", "aggregated_csp":
+		return rp.AggregatedCSPRangeProofType
 	}
 	panic(fmt.Errorf("invalid proof_type: %s", str))
 }
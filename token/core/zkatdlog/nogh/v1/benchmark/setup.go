@@ -50,16 +50,24 @@ type SetupConfigurationSer struct {
 //
 // # Range-proof system selection
 //
-// PublicParams supports up to two range-proof systems simultaneously
-// (RangeProofType / BulletProof and CSPRangeProofType / CSP). At least one
-// must be configured; both may be present at the same time, for example
+// PublicParams supports up to four range-proof systems simultaneously
+// (RangeProofType / BulletProof, CSPRangeProofType / CSP,
+// AggregatedRangeProofType / aggregated BulletProof, and
+// AggregatedCSPRangeProofType / aggregated CSP). At least one
+// must be configured; several may be present at the same time, for example
 // during a range-proof migration window.
 //
 // ProofType controls which params sub-struct is generated for the benchmark:
 //   - rp.RangeProofType: generates BulletProof (RangeProofParams) only.
 //   - rp.CSPRangeProofType: generates CSP (CSPRangeProofParams) only.
+//   - rp.AggregatedRangeProofType: generates aggregated BulletProof
+//     (AggregatedRangeProofParams) only.
+//   - rp.AggregatedCSPRangeProofType: generates aggregated CSP
+//     (AggregatedCSPRangeProofParams) only.
 //
-// When proving (prover-side), the driver prefers CSP if CSPRangeProofParams
+// When proving (prover-side), the driver prefers aggregated BulletProof if
+// AggregatedRangeProofParams is non-nil, then aggregated CSP if
+// AggregatedCSPRangeProofParams is non-nil, then CSP if CSPRangeProofParams
 // is non-nil, and falls back to BulletProof otherwise (see NewProver in
 // transfer/issue packages). On the verifier side, the algorithm is fixed by
 // the proof type recorded in the action, which is validated against the
@@ -71,7 +79,8 @@ type SetupParams struct {
 	OwnerIdentityType  identity.Type
 	// ProofType selects which range-proof system's parameters are generated
 	// for this benchmark configuration. Valid values: rp.RangeProofType
-	// (BulletProof) or rp.CSPRangeProofType (CSP).
+	// (BulletProof), rp.CSPRangeProofType (CSP), rp.AggregatedRangeProofType
+	// (aggregated BulletProof), or rp.AggregatedCSPRangeProofType (aggregated CSP).
 	ProofType rp.ProofType
 	// ExecutorProvider controls how independent range proofs are executed.
 	// If nil, executor.SerialProvider{} is used (serial execution, zero overhead).
@@ -120,7 +129,9 @@ func NewSetupConfigurations(idemixTestdataPath string, bits []uint64, curveIDs [
 // NewSetupConfigurationsWithParams loads test data and builds setup configurations
 // for each combination of the provided parameters. The ProofType field in params
 // determines which range-proof parameters to generate: rp.RangeProofType produces
-// BulletProof parameters, rp.CSPRangeProofType produces CSP parameters. Both sets
+// BulletProof parameters, rp.CSPRangeProofType produces CSP parameters,
+// rp.AggregatedRangeProofType produces aggregated BulletProof parameters, and
+// rp.AggregatedCSPRangeProofType produces aggregated CSP parameters. Several sets
 // may coexist in a single PublicParams (e.g. for migration); use
 // pp.GenerateRangeProofParameters or pp.GenerateCSPRangeProofParameters after
 // setup to add a second system to an existing configuration.
@@ -182,6 +193,24 @@ func NewSetupConfigurationsWithParams(params SetupParams) (*SetupConfigurations,
 					ProofType:      rp.CSPRangeProofType,
 					CurveID:        curveID,
 				})
+			case rp.AggregatedRangeProofType:
+				pp, err = setup.NewWith(setup.SetupParams{
+					DriverName:     setup.DLogNoGHDriverName,
+					DriverVersion:  setup.ProtocolV1,
+					BitLength:      bit,
+					IdemixIssuerPK: ipk,
+					ProofType:      rp.AggregatedRangeProofType,
+					CurveID:        curveID,
+				})
+			case rp.AggregatedCSPRangeProofType:
+				pp, err = setup.NewWith(setup.SetupParams{
+					DriverName:     setup.DLogNoGHDriverName,
+					DriverVersion:  setup.ProtocolV1,
+					BitLength:      bit,
+					IdemixIssuerPK: ipk,
+					ProofType:      rp.AggregatedCSPRangeProofType,
+					CurveID:        curveID,
+				})
 			default:
 				return nil, errors.Errorf("unrecognized proof type: %d", params.ProofType)
 			}
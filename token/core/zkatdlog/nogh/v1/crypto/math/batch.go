@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package math
+
+import (
+	"io"
+	"sync"
+
+	mathlib "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+)
+
+// ErrBatchVerificationFailed is returned when at least one of the equations collected by a BatchVerifier does not hold.
+var ErrBatchVerificationFailed = errors.New("batch verification failed")
+
+// BatchVerifier collects group equations of the form \sum_i s_i·P_i = 0 instead of checking them one by one.
+// Verify checks all of them with a single multi-scalar multiplication, after multiplying each equation
+// by a fresh random scalar, so that a false equation makes the check fail except with negligible probability.
+// A BatchVerifier can be used concurrently.
+type BatchVerifier struct {
+	Curve *mathlib.Curve
+
+	mu      sync.Mutex
+	rand    io.Reader
+	points  []*mathlib.G1
+	scalars []*mathlib.Zr
+}
+
+// NewBatchVerifier returns a new empty BatchVerifier for the passed curve.
+func NewBatchVerifier(curve *mathlib.Curve) (*BatchVerifier, error) {
+	rand, err := curve.Rand()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get random number generator")
+	}
+
+	return &BatchVerifier{Curve: curve, rand: rand}, nil
+}
+
+// Defer postpones the check that \sum_i scalars[i]·points[i] is the identity.
+// The passed points must not be modified afterward.
+func (b *BatchVerifier) Defer(points []*mathlib.G1, scalars []*mathlib.Zr) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rho := b.Curve.NewRandomZr(b.rand)
+	for i := range points {
+		b.points = append(b.points, points[i])
+		b.scalars = append(b.scalars, b.Curve.ModMul(scalars[i], rho, b.Curve.GroupOrder))
+	}
+}
+
+// DeferEquality postpones the check that lhs equals rhs.
+func (b *BatchVerifier) DeferEquality(lhs, rhs *mathlib.G1) {
+	minusOne := b.Curve.NewZrFromInt(1)
+	minusOne.Neg()
+	b.Defer([]*mathlib.G1{lhs, rhs}, []*mathlib.Zr{b.Curve.NewZrFromInt(1), minusOne})
+}
+
+// Len returns the number of terms collected so far.
+func (b *BatchVerifier) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.points)
+}
+
+// Verify checks all the postponed equations at once.
+// It returns ErrBatchVerificationFailed if at least one of them does not hold.
+func (b *BatchVerifier) Verify() error {
+	return VerifyBatches(b)
+}
+
+// VerifyBatches checks the equations postponed in all the passed BatchVerifiers with a single multi-scalar multiplication.
+// All BatchVerifiers must refer to the same curve.
+// It returns ErrBatchVerificationFailed if at least one of the equations does not hold.
+func VerifyBatches(batches ...*BatchVerifier) error {
+	var curve *mathlib.Curve
+	var points []*mathlib.G1
+	var scalars []*mathlib.Zr
+	for _, b := range batches {
+		if b == nil {
+			continue
+		}
+		if curve == nil {
+			curve = b.Curve
+		} else if curve.ID() != b.Curve.ID() {
+			return errors.Errorf("cannot verify batches over different curves [%d vs %d]", curve.ID(), b.Curve.ID())
+		}
+		b.mu.Lock()
+		points = append(points, b.points...)
+		scalars = append(scalars, b.scalars...)
+		b.mu.Unlock()
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	if !curve.MultiScalarMul(points, scalars).IsInfinity() {
+		return ErrBatchVerificationFailed
+	}
+
+	return nil
+}
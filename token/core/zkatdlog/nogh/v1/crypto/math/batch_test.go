@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package math
+
+import (
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	curve := math.Curves[math.BN254]
+	rand, err := curve.Rand()
+	require.NoError(t, err)
+
+	x := curve.NewRandomZr(rand)
+	y := curve.NewRandomZr(rand)
+	g := curve.GenG1.Mul(curve.NewRandomZr(rand))
+	h := curve.GenG1.Mul(curve.NewRandomZr(rand))
+
+	// empty batch
+	b, err := NewBatchVerifier(curve)
+	require.NoError(t, err)
+	require.NoError(t, b.Verify())
+
+	// x·g + y·h - (x·g + y·h) == 0
+	lhs := curve.MultiScalarMul([]*math.G1{g, h}, []*math.Zr{x, y})
+	minusOne := curve.NewZrFromInt(1)
+	minusOne.Neg()
+	b.Defer([]*math.G1{g, h, lhs}, []*math.Zr{x, y, minusOne})
+	b.DeferEquality(g.Mul(x), g.Mul(x))
+	require.Equal(t, 5, b.Len())
+	require.NoError(t, b.Verify())
+
+	// a false equation makes the whole batch fail
+	bad, err := NewBatchVerifier(curve)
+	require.NoError(t, err)
+	bad.DeferEquality(g.Mul(x), g.Mul(y))
+	require.ErrorIs(t, bad.Verify(), ErrBatchVerificationFailed)
+	require.ErrorIs(t, VerifyBatches(b, nil, bad), ErrBatchVerificationFailed)
+	require.NoError(t, VerifyBatches(b, nil))
+
+	// batches over different curves cannot be merged
+	other, err := NewBatchVerifier(math.Curves[math.BLS12_381_BBS])
+	require.NoError(t, err)
+	require.Error(t, VerifyBatches(b, other))
+}
@@ -0,0 +1,407 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bulletproof
+
+import (
+	"math/bits"
+
+	math "github.com/IBM/mathlib"
+	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/common"
+	math2 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/math"
+	executor "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/rp/executor"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+)
+
+// aggregatedRangeProver produces a single range proof for m committed values,
+// where m is a power of two. The proof has the same structure as a RangeProof
+// but its inner product argument runs over vectors of length m*BitLength,
+// so its size grows with log(m) instead of m.
+type aggregatedRangeProver struct {
+	// values are the committed values to be proven within range.
+	values []uint64
+	// blindingFactors are the randomness used for the value commitments.
+	blindingFactors []*math.Zr
+	// Commitments are the Pedersen commitments G^v_j H^r_j.
+	Commitments []*math.G1
+	// CommitmentGenerators are the (G, H) generators.
+	CommitmentGenerators []*math.G1
+	// LeftGenerators are the generators for the left vector, at least m*BitLength.
+	LeftGenerators []*math.G1
+	// RightGenerators are the generators for the right vector, at least m*BitLength.
+	RightGenerators []*math.G1
+	// P is an auxiliary generator.
+	P *math.G1
+	// Q is an auxiliary generator for the inner product.
+	Q *math.G1
+	// BitLength is the maximum number of bits for each value.
+	BitLength uint64
+	// Curve is the mathematical curve.
+	Curve *math.Curve
+	// Provider creates a fresh Executor for each Prove call.
+	Provider executor.ExecutorProvider
+}
+
+// NewAggregatedRangeProver returns a prover of a single range proof for the passed commitments.
+// The number of commitments must be a power of two.
+func NewAggregatedRangeProver(
+	coms []*math.G1,
+	values []uint64,
+	commitmentGen []*math.G1,
+	blindingFactors []*math.Zr,
+	leftGen []*math.G1,
+	rightGen []*math.G1,
+	P, Q *math.G1,
+	bitLength uint64,
+	curve *math.Curve,
+	provider executor.ExecutorProvider,
+) *aggregatedRangeProver {
+	return &aggregatedRangeProver{
+		Commitments:          coms,
+		values:               values,
+		CommitmentGenerators: commitmentGen,
+		blindingFactors:      blindingFactors,
+		LeftGenerators:       leftGen,
+		RightGenerators:      rightGen,
+		P:                    P,
+		Q:                    Q,
+		BitLength:            bitLength,
+		Curve:                curve,
+		Provider:             provider,
+	}
+}
+
+// Prove produces a RangeProof that shows that each committed value
+// v_j = \sum_{i=0}^{BitLength} b_{j,i} 2^i; b_{j,i} in {0, 1}
+func (p *aggregatedRangeProver) Prove() (*RangeProof, error) {
+	m := len(p.Commitments)
+	if m == 0 || m&(m-1) != 0 {
+		return nil, errors.Errorf("invalid aggregated range proof: the number of commitments [%d] is not a power of two", m)
+	}
+	if len(p.values) != m || len(p.blindingFactors) != m {
+		return nil, errors.New("invalid aggregated range proof: the number of values and blinding factors does not match the number of commitments")
+	}
+	size := m * int(p.BitLength) // #nosec G115
+	if len(p.LeftGenerators) < size || len(p.RightGenerators) < size {
+		return nil, errors.Errorf("invalid aggregated range proof: [%d] generators are needed", size)
+	}
+	leftGenerators, rightGenerators := p.LeftGenerators[:size], p.RightGenerators[:size]
+	c := p.Curve
+	one := math2.One(c)
+
+	rand, err := c.Rand()
+	if err != nil {
+		return nil, err
+	}
+	// left = (b_{0,0}, ..., b_{m-1,BitLength-1}) and right = left - 1
+	left := make([]*math.Zr, size)
+	right := make([]*math.Zr, size)
+	randomLeft := make([]*math.Zr, size)
+	randomRight := make([]*math.Zr, size)
+	for j, value := range p.values {
+		for i := range p.BitLength {
+			b := uint64(0)
+			if value&(1<<i) != 0 {
+				b = 1
+			}
+			k := uint64(j)*p.BitLength + i // #nosec G115
+			left[k] = math2.NewCachedZrFromInt(c, b)
+			right[k] = c.ModSub(left[k], one, c.GroupOrder)
+			randomLeft[k] = c.NewRandomZr(rand)
+			randomRight[k] = c.NewRandomZr(rand)
+		}
+	}
+	rho := c.NewRandomZr(rand)
+	eta := c.NewRandomZr(rand)
+	C := CommitVectorPlusOne(left, right, leftGenerators, rightGenerators, rho, p.P, c)
+	D := CommitVectorPlusOne(randomLeft, randomRight, leftGenerators, rightGenerators, eta, p.P, c)
+
+	y, z, err := aggregatedChallengesYZ(C, D, p.Commitments, c)
+	if err != nil {
+		return nil, err
+	}
+	yPow := powers(y, size, c)
+	zPrime := aggregatedZPrime(z, m, p.BitLength, c)
+
+	leftPrime := make([]*math.Zr, size)
+	rightPrime := make([]*math.Zr, size)
+	randRightPrime := make([]*math.Zr, size)
+	for k := range size {
+		// L_k - z
+		leftPrime[k] = c.ModSub(left[k], z, c.GroupOrder)
+		// y^k(R_k + z)
+		rightPrime[k] = c.ModMul(c.ModAdd(right[k], z, c.GroupOrder), yPow[k], c.GroupOrder)
+		// y^kV_k
+		randRightPrime[k] = c.ModMul(randomRight[k], yPow[k], c.GroupOrder)
+	}
+	// t1 = \sum y^kV_k(L_k-z) + (y^k(R_k+z) + z^{2+j}2^i)U_k
+	t1 := math2.InnerProduct(leftPrime, randRightPrime, c)
+	for k := range size {
+		t1 = c.ModAdd(t1, c.ModMul(c.ModAdd(rightPrime[k], zPrime[k], c.GroupOrder), randomLeft[k], c.GroupOrder), c.GroupOrder)
+	}
+	// t2 = \sum y^kU_kV_k
+	t2 := math2.InnerProduct(randomLeft, randRightPrime, c)
+	tau1 := c.NewRandomZr(rand)
+	tau2 := c.NewRandomZr(rand)
+	T1 := p.CommitmentGenerators[0].Mul2(t1, p.CommitmentGenerators[1], tau1)
+	T2 := p.CommitmentGenerators[0].Mul2(t2, p.CommitmentGenerators[1], tau2)
+
+	x, err := aggregatedChallengeX(T1, T2, z, c)
+	if err != nil {
+		return nil, err
+	}
+	for k := range size {
+		// (L_k-z) + xU_k
+		left[k] = c.ModAddMul2(leftPrime[k], one, x, randomLeft[k], c.GroupOrder)
+		// y^k((R_k+z)+xV_k) + z^{2+j}2^i
+		right[k] = c.ModAdd(rightPrime[k], c.ModMul(x, randRightPrime[k], c.GroupOrder), c.GroupOrder)
+		right[k] = c.ModAdd(right[k], zPrime[k], c.GroupOrder)
+	}
+	// tau = tau1x + tau2x^2 + \sum z^{2+j}r_j
+	tau := c.ModAddMul2(tau1, x, tau2, c.ModMul(x, x, c.GroupOrder), c.GroupOrder)
+	zj := c.ModMul(z, z, c.GroupOrder)
+	for _, bf := range p.blindingFactors {
+		tau = c.ModAdd(tau, c.ModMul(zj, bf, c.GroupOrder), c.GroupOrder)
+		zj = c.ModMul(zj, z, c.GroupOrder)
+	}
+
+	rp := &RangeProof{
+		Data: &RangeProofData{
+			T1:           T1,
+			T2:           T2,
+			C:            C,
+			D:            D,
+			Tau:          tau,
+			Delta:        c.ModAdd(rho, c.ModMul(eta, x, c.GroupOrder), c.GroupOrder),
+			InnerProduct: math2.InnerProduct(left, right, c),
+		},
+	}
+
+	// the IPA runs against the generators H'_k = H_k^{1/y^k}
+	yInv := math2.BatchInverse(yPow, c)
+	rightGeneratorsPrime := make([]*math.G1, size)
+	for k := range size {
+		rightGeneratorsPrime[k] = rightGenerators[k].Mul(yInv[k])
+	}
+	com := CommitVector(left, right, leftGenerators, rightGeneratorsPrime, c)
+	rp.IPA, err = NewIPAProver(
+		rp.Data.InnerProduct,
+		left,
+		right,
+		p.Q,
+		leftGenerators,
+		rightGeneratorsPrime,
+		com,
+		log2(size),
+		c,
+		p.Provider,
+	).Prove()
+	if err != nil {
+		return nil, err
+	}
+
+	return rp, nil
+}
+
+// aggregatedRangeVerifier checks a range proof produced by an aggregatedRangeProver.
+type aggregatedRangeVerifier struct {
+	// Commitments are the Pedersen commitments to be verified.
+	Commitments []*math.G1
+	// CommitmentGenerators are the (G, H) generators.
+	CommitmentGenerators []*math.G1
+	// LeftGenerators are the generators for the left vector, at least m*BitLength.
+	LeftGenerators []*math.G1
+	// RightGenerators are the generators for the right vector, at least m*BitLength.
+	RightGenerators []*math.G1
+	// P is an auxiliary generator.
+	P *math.G1
+	// Q is an auxiliary generator for the inner product.
+	Q *math.G1
+	// BitLength is the maximum number of bits for each value.
+	BitLength uint64
+	// Curve is the mathematical curve.
+	Curve *math.Curve
+	// Provider creates a fresh Executor for each Verify call.
+	Provider executor.ExecutorProvider
+	// Batch, if not nil, collects the final group equations instead of checking them.
+	// The proof is then valid only if Batch.Verify succeeds.
+	Batch *math2.BatchVerifier
+}
+
+// NewAggregatedRangeVerifier returns a verifier of a single range proof for the passed commitments.
+// The number of commitments must be a power of two.
+func NewAggregatedRangeVerifier(
+	coms []*math.G1,
+	commitmentGen []*math.G1,
+	leftGen []*math.G1,
+	rightGen []*math.G1,
+	P, Q *math.G1,
+	bitLength uint64,
+	curve *math.Curve,
+	provider executor.ExecutorProvider,
+) *aggregatedRangeVerifier {
+	return &aggregatedRangeVerifier{
+		Commitments:          coms,
+		CommitmentGenerators: commitmentGen,
+		LeftGenerators:       leftGen,
+		RightGenerators:      rightGen,
+		P:                    P,
+		Q:                    Q,
+		BitLength:            bitLength,
+		Curve:                curve,
+		Provider:             provider,
+	}
+}
+
+// Verify checks the validity of an aggregated RangeProof.
+// It returns nil if the proof is valid, or an error otherwise.
+func (v *aggregatedRangeVerifier) Verify(rp *RangeProof) error {
+	m := len(v.Commitments)
+	if m == 0 || m&(m-1) != 0 {
+		return errors.Errorf("invalid aggregated range proof: the number of commitments [%d] is not a power of two", m)
+	}
+	size := m * int(v.BitLength) // #nosec G115
+	if len(v.LeftGenerators) < size || len(v.RightGenerators) < size {
+		return errors.Errorf("invalid aggregated range proof: [%d] generators are needed", size)
+	}
+	if rp == nil || rp.Data == nil || rp.IPA == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
+	if rp.Data.InnerProduct == nil || rp.Data.C == nil || rp.Data.D == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
+	if rp.Data.T1 == nil || rp.Data.T2 == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
+	if rp.Data.Tau == nil || rp.Data.Delta == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
+	leftGenerators, rightGenerators := v.LeftGenerators[:size], v.RightGenerators[:size]
+	c := v.Curve
+
+	y, z, err := aggregatedChallengesYZ(rp.Data.C, rp.Data.D, v.Commitments, c)
+	if err != nil {
+		return err
+	}
+	x, err := aggregatedChallengeX(rp.Data.T1, rp.Data.T2, z, c)
+	if err != nil {
+		return err
+	}
+	yPow := powers(y, size, c)
+	zPrime := aggregatedZPrime(z, m, v.BitLength, c)
+
+	// polEval = (z - z^2)\sum y^k - \sum_j z^{3+j} \sum_i 2^i
+	ipy := math2.Zero(c)
+	for _, yk := range yPow {
+		ipy = c.ModAdd(ipy, yk, c.GroupOrder)
+	}
+	zSquare := c.ModMul(z, z, c.GroupOrder)
+	polEval := c.ModMul(c.ModSub(z, zSquare, c.GroupOrder), ipy, c.GroupOrder)
+	ip2 := math2.SumOfPowersOfTwo(c, v.BitLength)
+	comPoints := make([]*math.G1, 0, m+1)
+	comScalars := make([]*math.Zr, 0, m+1)
+	// zj = z^{2+j}
+	zj := zSquare
+	for _, com := range v.Commitments {
+		polEval = c.ModSub(polEval, c.ModMul(c.ModMul(zj, z, c.GroupOrder), ip2, c.GroupOrder), c.GroupOrder)
+		comPoints = append(comPoints, com)
+		comScalars = append(comScalars, zj)
+		zj = c.ModMul(zj, z, c.GroupOrder)
+	}
+	comPoints = append(comPoints, v.CommitmentGenerators[0])
+	comScalars = append(comScalars, polEval)
+
+	// G^InnerProduct H^Tau T1^{-x} T2^{-x^2} should be equal to \prod V_j^{z^{2+j}} G^polEval
+	com := v.CommitmentGenerators[0].Mul2(rp.Data.InnerProduct, v.CommitmentGenerators[1], rp.Data.Tau)
+	com.Sub(rp.Data.T1.Mul(x))
+	com.Sub(rp.Data.T2.Mul(c.ModMul(x, x, c.GroupOrder)))
+	if v.Batch != nil {
+		v.Batch.DeferEquality(com, c.MultiScalarMul(comPoints, comScalars))
+	} else if !com.Equals(c.MultiScalarMul(comPoints, comScalars)) {
+		return errors.New("invalid range proof")
+	}
+
+	// reconstruct the commitment to the vectors of the IPA:
+	// D^x C \prod G_k^{-z} H'_k^{zy^k + z^{2+j}2^i} P^{-Delta}
+	yInv := math2.BatchInverse(yPow, c)
+	rightGeneratorsPrime := make([]*math.G1, size)
+	zero, one := math2.Zero(c), math2.One(c)
+	zNeg := c.ModSub(zero, z, c.GroupOrder)
+	points := make([]*math.G1, 0, 2*size+3)
+	scalars := make([]*math.Zr, 0, 2*size+3)
+	points = append(points, rp.Data.D, rp.Data.C, v.P)
+	scalars = append(scalars, x, one, c.ModSub(zero, rp.Data.Delta, c.GroupOrder))
+	for k := range size {
+		rightGeneratorsPrime[k] = rightGenerators[k].Mul(yInv[k])
+		points = append(points, leftGenerators[k], rightGeneratorsPrime[k])
+		scalars = append(scalars, zNeg, c.ModAddMul2(z, yPow[k], one, zPrime[k], c.GroupOrder))
+	}
+
+	ipv := NewIPAVerifier(
+		rp.Data.InnerProduct,
+		v.Q,
+		leftGenerators,
+		rightGeneratorsPrime,
+		c.MultiScalarMul(points, scalars),
+		log2(size),
+		c,
+		v.Provider,
+	)
+	ipv.Batch = v.Batch
+
+	return ipv.Verify(rp.IPA)
+}
+
+// aggregatedChallengesYZ derives the challenges y and z from the commitments to the bit vectors and to the values
+func aggregatedChallengesYZ(C, D *math.G1, coms []*math.G1, c *math.Curve) (*math.Zr, *math.Zr, error) {
+	bytesToHash, err := common.GetG1Array([]*math.G1{C, D}, coms).Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	y := c.HashToZr(bytesToHash)
+	z := c.HashToZr(y.Bytes())
+
+	return y, z, nil
+}
+
+// aggregatedChallengeX derives the challenge x from the commitments to t1 and t2, bound to z
+func aggregatedChallengeX(T1, T2 *math.G1, z *math.Zr, c *math.Curve) (*math.Zr, error) {
+	bytesToHash, err := common.GetG1Array([]*math.G1{T1, T2}).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.HashToZr(append(bytesToHash, z.Bytes()...)), nil
+}
+
+// aggregatedZPrime returns the vector whose (j*bitLength+i)-th entry is z^{2+j}2^i
+func aggregatedZPrime(z *math.Zr, m int, bitLength uint64, c *math.Curve) []*math.Zr {
+	zPrime := make([]*math.Zr, 0, uint64(m)*bitLength) // #nosec G115
+	zj := c.ModMul(z, z, c.GroupOrder)
+	for range m {
+		for i := range bitLength {
+			zPrime = append(zPrime, c.ModMul(zj, math2.PowerOfTwo(c, i), c.GroupOrder))
+		}
+		zj = c.ModMul(zj, z, c.GroupOrder)
+	}
+
+	return zPrime
+}
+
+// powers returns (1, y, ..., y^{n-1})
+func powers(y *math.Zr, n int, c *math.Curve) []*math.Zr {
+	res := make([]*math.Zr, n)
+	res[0] = math2.One(c)
+	for i := 1; i < n; i++ {
+		res[i] = c.ModMul(res[i-1], y, c.GroupOrder)
+	}
+
+	return res
+}
+
+func log2(n int) uint64 {
+	return uint64(bits.Len(uint(n)) - 1) // #nosec G115
+}
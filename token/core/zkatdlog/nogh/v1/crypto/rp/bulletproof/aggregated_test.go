@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bulletproof
+
+import (
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type aggregatedSetup struct {
+	curve          *math.Curve
+	pedersenParams []*math.G1
+	leftGens       []*math.G1
+	rightGens      []*math.G1
+	P, Q           *math.G1
+	bitLength      uint64
+	maxAggregation uint64
+}
+
+func newAggregatedSetup(t *testing.T, curveID math.CurveID, bitLength, maxAggregation uint64) *aggregatedSetup {
+	t.Helper()
+	curve := math.Curves[curveID]
+	rand, err := curve.Rand()
+	require.NoError(t, err)
+	s := &aggregatedSetup{
+		curve:          curve,
+		pedersenParams: []*math.G1{curve.GenG1.Mul(curve.NewRandomZr(rand)), curve.GenG1.Mul(curve.NewRandomZr(rand))},
+		leftGens:       make([]*math.G1, bitLength*maxAggregation),
+		rightGens:      make([]*math.G1, bitLength*maxAggregation),
+		P:              curve.GenG1.Mul(curve.NewRandomZr(rand)),
+		Q:              curve.GenG1.Mul(curve.NewRandomZr(rand)),
+		bitLength:      bitLength,
+		maxAggregation: maxAggregation,
+	}
+	for i := range s.leftGens {
+		s.leftGens[i] = curve.GenG1.Mul(curve.NewRandomZr(rand))
+		s.rightGens[i] = curve.GenG1.Mul(curve.NewRandomZr(rand))
+	}
+
+	return s
+}
+
+func (s *aggregatedSetup) commit(t *testing.T, values []uint64) ([]*math.G1, []*math.Zr) {
+	t.Helper()
+	rand, err := s.curve.Rand()
+	require.NoError(t, err)
+	coms := make([]*math.G1, len(values))
+	bfs := make([]*math.Zr, len(values))
+	for i, v := range values {
+		bfs[i] = s.curve.NewRandomZr(rand)
+		coms[i] = s.pedersenParams[0].Mul2(s.curve.NewZrFromUint64(v), s.pedersenParams[1], bfs[i])
+	}
+
+	return coms, bfs
+}
+
+func (s *aggregatedSetup) prove(t *testing.T, coms []*math.G1, values []uint64, bfs []*math.Zr) *RangeCorrectness {
+	t.Helper()
+	rc, err := NewAggregatedRangeCorrectnessProver(coms, values, bfs, s.pedersenParams, s.leftGens, s.rightGens, s.P, s.Q, s.bitLength, s.maxAggregation, s.curve, nil).Prove()
+	require.NoError(t, err)
+
+	return rc
+}
+
+func (s *aggregatedSetup) verifier(coms []*math.G1) *RangeCorrectnessVerifier {
+	v := NewAggregatedRangeCorrectnessVerifier(s.pedersenParams, s.leftGens, s.rightGens, s.P, s.Q, s.bitLength, s.maxAggregation, s.curve, nil)
+	v.Commitments = coms
+
+	return v
+}
+
+func TestAggregatedRangeCorrectness(t *testing.T) {
+	for _, curveID := range []math.CurveID{math.BN254, math.BLS12_381_BBS_GURVY} {
+		s := newAggregatedSetup(t, curveID, 16, 4)
+		for _, values := range [][]uint64{
+			{7},
+			{1, 65535},
+			{0, 1, 2},
+			{10, 20, 30, 40},
+			{1, 2, 3, 4, 5, 6},
+		} {
+			coms, bfs := s.commit(t, values)
+			rc := s.prove(t, coms, values, bfs)
+			require.Len(t, rc.Proofs, (len(values)+3)/4)
+			require.NoError(t, rc.Validate(curveID))
+
+			raw, err := rc.Serialize()
+			require.NoError(t, err)
+			rc2 := &RangeCorrectness{}
+			require.NoError(t, rc2.Deserialize(raw))
+			require.NoError(t, s.verifier(coms).Verify(rc2), "values %v", values)
+		}
+	}
+}
+
+func TestAggregatedRangeCorrectnessSize(t *testing.T) {
+	s := newAggregatedSetup(t, math.BN254, 64, 8)
+	values := []uint64{1, 2, 3, 4, 5, 6, 7, 8}
+	coms, bfs := s.commit(t, values)
+	aggregated, err := s.prove(t, coms, values, bfs).Serialize()
+	require.NoError(t, err)
+	single, err := NewRangeCorrectnessProver(coms, values, bfs, s.pedersenParams, s.leftGens[:64], s.rightGens[:64], s.P, s.Q, 64, 6, s.curve, nil).Prove()
+	require.NoError(t, err)
+	singleRaw, err := single.Serialize()
+	require.NoError(t, err)
+	assert.Less(t, 3*len(aggregated), len(singleRaw))
+}
+
+func TestAggregatedRangeCorrectnessFailures(t *testing.T) {
+	s := newAggregatedSetup(t, math.BN254, 16, 4)
+	values := []uint64{1, 2, 3}
+	coms, bfs := s.commit(t, values)
+	rc := s.prove(t, coms, values, bfs)
+
+	// a commitment is swapped
+	swapped := []*math.G1{coms[1], coms[0], coms[2]}
+	require.Error(t, s.verifier(swapped).Verify(rc))
+
+	// a commitment is missing
+	require.Error(t, s.verifier(coms[:2]).Verify(rc))
+
+	// a value out of range
+	outOfRange := []uint64{1, 1 << 16, 3}
+	coms2, bfs2 := s.commit(t, outOfRange)
+	rc2 := s.prove(t, coms2, outOfRange, bfs2)
+	require.Error(t, s.verifier(coms2).Verify(rc2))
+
+	// the proofs of another set of commitments
+	require.Error(t, s.verifier(coms).Verify(rc2))
+
+	// tampered proof
+	rc.Proofs[0].Data.Tau = s.curve.NewZrFromUint64(1)
+	require.Error(t, s.verifier(coms).Verify(rc))
+
+	// not enough generators for the aggregation
+	values = []uint64{1, 2, 3, 4, 5}
+	coms, bfs = s.commit(t, values)
+	_, err := NewAggregatedRangeCorrectnessProver(coms, values, bfs, s.pedersenParams, s.leftGens, s.rightGens, s.P, s.Q, s.bitLength, 8, s.curve, nil).Prove()
+	require.Error(t, err)
+}
@@ -293,6 +293,9 @@ type ipaVerifier struct {
 	// Provider creates a fresh Executor for each Prove call.
 	// If nil, DefaultProvider (SerialProvider) is used.
 	Provider executor.ExecutorProvider
+	// Batch, if not nil, collects the final group equations instead of checking them.
+	// The proof is then valid only if Batch.Verify succeeds.
+	Batch *math.BatchVerifier
 }
 
 // NewIPAVerifier returns an ipaVerifier instance.
@@ -417,6 +420,14 @@ func (v *ipaVerifier) Verify(proof *IPA) error {
 
 	generators[idx] = X.Copy()
 	scalars[idx] = v.Curve.ModMul(proof.Left, proof.Right, v.Curve.GroupOrder)
+	if v.Batch != nil {
+		// postpone the check that the MSM equals C
+		minusOne := v.Curve.NewZrFromInt(1)
+		minusOne.Neg()
+		v.Batch.Defer(append(generators, C), append(scalars, minusOne))
+
+		return nil
+	}
 	CPrime := v.Curve.MultiScalarMul(generators, scalars)
 	if !CPrime.Equals(C) {
 		return errors.New("invalid IPA")
@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package bulletproof
 
 import (
+	"math/bits"
+
 	math "github.com/IBM/mathlib"
 	"github.com/LFDT-Panurus/panurus/token/core/common/encoding/asn1"
+	math2 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/math"
 	executor "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/rp/executor"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 )
@@ -88,6 +91,9 @@ type RangeCorrectnessProver struct {
 	// Provider creates a fresh Executor for each Prove call.
 	// If nil, DefaultProvider (SerialProvider) is used.
 	Provider executor.ExecutorProvider
+	// MaxAggregation is the maximum number of commitments covered by a single aggregated proof.
+	// If zero, one proof is generated for each commitment.
+	MaxAggregation uint64
 }
 
 // NewRangeCorrectnessProver returns a new RangeCorrectnessProver.
@@ -123,8 +129,30 @@ func NewRangeCorrectnessProver(
 	}
 }
 
+// NewAggregatedRangeCorrectnessProver returns a new RangeCorrectnessProver that proves
+// the commitments in groups of at most maxAggregation, with one aggregated proof per group.
+// The generators must contain bitLength*maxAggregation elements.
+func NewAggregatedRangeCorrectnessProver(
+	coms []*math.G1,
+	values []uint64,
+	blindingFactors []*math.Zr,
+	pedersenParameters, leftGenerators, rightGenerators []*math.G1,
+	P, Q *math.G1,
+	bitLength, maxAggregation uint64,
+	c *math.Curve,
+	provider executor.ExecutorProvider,
+) *RangeCorrectnessProver {
+	p := NewRangeCorrectnessProver(coms, values, blindingFactors, pedersenParameters, leftGenerators, rightGenerators, P, Q, bitLength, log2(int(bitLength)), c, provider) // #nosec G115
+	p.MaxAggregation = maxAggregation
+
+	return p
+}
+
 // Prove generates a set of range proofs.
 func (p *RangeCorrectnessProver) Prove() (*RangeCorrectness, error) {
+	if p.MaxAggregation > 0 {
+		return p.proveAggregated()
+	}
 	n := len(p.Commitments)
 
 	rc := &RangeCorrectness{
@@ -189,6 +217,12 @@ type RangeCorrectnessVerifier struct {
 	// Provider creates a fresh Executor for each Prove call.
 	// If nil, DefaultProvider (SerialProvider) is used.
 	Provider executor.ExecutorProvider
+	// MaxAggregation is the maximum number of commitments covered by a single aggregated proof.
+	// If zero, one proof is expected for each commitment.
+	MaxAggregation uint64
+	// Batch, if not nil, collects the final group equations instead of checking them.
+	// The proof is then valid only if Batch.Verify succeeds.
+	Batch *math2.BatchVerifier
 }
 
 // NewRangeCorrectnessVerifier returns a new RangeCorrectnessVerifier.
@@ -218,8 +252,26 @@ func NewRangeCorrectnessVerifier(
 	}
 }
 
+// NewAggregatedRangeCorrectnessVerifier returns a new RangeCorrectnessVerifier that expects
+// one aggregated proof for each group of at most maxAggregation commitments.
+func NewAggregatedRangeCorrectnessVerifier(
+	pedersenParameters, leftGenerators, rightGenerators []*math.G1,
+	P, Q *math.G1,
+	bitLength, maxAggregation uint64,
+	curve *math.Curve,
+	provider executor.ExecutorProvider,
+) *RangeCorrectnessVerifier {
+	v := NewRangeCorrectnessVerifier(pedersenParameters, leftGenerators, rightGenerators, P, Q, bitLength, log2(int(bitLength)), curve, provider) // #nosec G115
+	v.MaxAggregation = maxAggregation
+
+	return v
+}
+
 // Verify checks if the provided set of range proofs is valid.
 func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
+	if v.MaxAggregation > 0 {
+		return v.verifyAggregated(rc)
+	}
 	if len(rc.Proofs) != len(v.Commitments) {
 		return errors.New("invalid range proof")
 	}
@@ -248,6 +300,7 @@ func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
 				v.Curve,
 				v.Provider,
 			)
+			bv.Batch = v.Batch
 
 			errs[i] = bv.Verify(rc.Proofs[i])
 		})
@@ -263,3 +316,119 @@ func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
 
 	return nil
 }
+
+// proveAggregated generates one aggregated range proof for each group of at most MaxAggregation commitments
+func (p *RangeCorrectnessProver) proveAggregated() (*RangeCorrectness, error) {
+	groups := aggregationGroups(len(p.Commitments), p.MaxAggregation)
+	rc := &RangeCorrectness{
+		Proofs: make([]*RangeProof, len(groups)),
+	}
+
+	executor := p.Provider.New()
+	errs := make([]error, len(groups))
+	for i, g := range groups {
+		executor.Submit(func() {
+			coms, values, blindingFactors := padAggregationGroup(p.Commitments[g.start:g.end], p.Curve)
+			copy(values, p.Values[g.start:g.end])
+			copy(blindingFactors, p.BlindingFactors[g.start:g.end])
+			rc.Proofs[i], errs[i] = NewAggregatedRangeProver(
+				coms,
+				values,
+				p.PedersenParameters,
+				blindingFactors,
+				p.LeftGenerators,
+				p.RightGenerators,
+				p.P,
+				p.Q,
+				p.BitLength,
+				p.Curve,
+				p.Provider,
+			).Prove()
+		})
+	}
+	executor.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rc, nil
+}
+
+// verifyAggregated checks that each group of at most MaxAggregation commitments has a valid aggregated range proof
+func (v *RangeCorrectnessVerifier) verifyAggregated(rc *RangeCorrectness) error {
+	groups := aggregationGroups(len(v.Commitments), v.MaxAggregation)
+	if len(rc.Proofs) != len(groups) {
+		return errors.New("invalid range proof")
+	}
+
+	executor := v.Provider.New()
+	errs := make([]error, len(groups))
+	for i, g := range groups {
+		executor.Submit(func() {
+			if rc.Proofs[i] == nil {
+				errs[i] = errors.Errorf("invalid range proof: nil proof at index %d", i)
+
+				return
+			}
+			coms, _, _ := padAggregationGroup(v.Commitments[g.start:g.end], v.Curve)
+			av := NewAggregatedRangeVerifier(
+				coms,
+				v.PedersenParameters,
+				v.LeftGenerators,
+				v.RightGenerators,
+				v.P,
+				v.Q,
+				v.BitLength,
+				v.Curve,
+				v.Provider,
+			)
+			av.Batch = v.Batch
+			errs[i] = av.Verify(rc.Proofs[i])
+		})
+	}
+	executor.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return errors.Wrapf(err, "invalid range proof at index %d", i)
+		}
+	}
+
+	return nil
+}
+
+// aggregationGroup is the range [start, end) of the commitments covered by an aggregated proof
+type aggregationGroup struct {
+	start, end int
+}
+
+// aggregationGroups splits n commitments in consecutive groups of at most maxAggregation commitments
+func aggregationGroups(n int, maxAggregation uint64) []aggregationGroup {
+	size := int(maxAggregation) // #nosec G115
+	groups := make([]aggregationGroup, 0, (n+size-1)/size)
+	for start := 0; start < n; start += size {
+		groups = append(groups, aggregationGroup{start: start, end: min(start+size, n)})
+	}
+
+	return groups
+}
+
+// padAggregationGroup pads the passed commitments to the next power of two with commitments to zero,
+// the identity element, and returns zero-filled values and blinding factors of the same length
+func padAggregationGroup(coms []*math.G1, c *math.Curve) ([]*math.G1, []uint64, []*math.Zr) {
+	m := 1 << bits.Len(uint(len(coms)-1))
+	padded := make([]*math.G1, m)
+	copy(padded, coms)
+	blindingFactors := make([]*math.Zr, m)
+	for i := range m {
+		if i >= len(coms) {
+			padded[i] = c.NewG1()
+		}
+		blindingFactors[i] = math2.Zero(c)
+	}
+
+	return padded, make([]uint64, m), blindingFactors
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	math "github.com/IBM/mathlib"
+	math2 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/math"
 	"github.com/stretchr/testify/require"
 )
 
@@ -118,3 +119,63 @@ func TestRangeCorrectness(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid range proof: nil proof at index 0")
 }
+
+func TestRangeCorrectnessBatch(t *testing.T) {
+	curve := math.Curves[math.BN254]
+	bitLength := uint64(16)
+	rounds := uint64(4)
+
+	rand, err := curve.Rand()
+	require.NoError(t, err)
+
+	pedersenParams := []*math.G1{curve.GenG1, curve.GenG1.Mul(curve.NewRandomZr(rand))}
+	leftGens := make([]*math.G1, 2*bitLength)
+	rightGens := make([]*math.G1, 2*bitLength)
+	for i := range leftGens {
+		leftGens[i] = curve.GenG1.Mul(curve.NewRandomZr(rand))
+		rightGens[i] = curve.GenG1.Mul(curve.NewRandomZr(rand))
+	}
+	P := curve.GenG1.Mul(curve.NewRandomZr(rand))
+	Q := curve.GenG1.Mul(curve.NewRandomZr(rand))
+
+	values := []uint64{10, 20}
+	blindingFactors := []*math.Zr{curve.NewRandomZr(rand), curve.NewRandomZr(rand)}
+	commitments := make([]*math.G1, len(values))
+	for i := range values {
+		commitments[i] = pedersenParams[0].Mul(curve.NewZrFromUint64(values[i]))
+		commitments[i].Add(pedersenParams[1].Mul(blindingFactors[i]))
+	}
+
+	for _, maxAggregation := range []uint64{0, 2} {
+		// aggregated proofs need bitLength*maxAggregation generators
+		size := bitLength * max(maxAggregation, 1)
+		leftGens, rightGens := leftGens[:size], rightGens[:size]
+		prover := NewRangeCorrectnessProver(commitments, values, blindingFactors, pedersenParams, leftGens, rightGens, P, Q, bitLength, rounds, curve, nil)
+		prover.MaxAggregation = maxAggregation
+		rc, err := prover.Prove()
+		require.NoError(t, err)
+
+		newVerifier := func(commitments []*math.G1) (*RangeCorrectnessVerifier, *math2.BatchVerifier) {
+			batch, err := math2.NewBatchVerifier(curve)
+			require.NoError(t, err)
+			verifier := NewRangeCorrectnessVerifier(pedersenParams, leftGens, rightGens, P, Q, bitLength, rounds, curve, nil)
+			verifier.MaxAggregation = maxAggregation
+			verifier.Commitments = commitments
+			verifier.Batch = batch
+
+			return verifier, batch
+		}
+
+		// a valid proof defers its group checks, and the batch verifies
+		verifier, batch := newVerifier(commitments)
+		require.NoError(t, verifier.Verify(rc))
+		require.Positive(t, batch.Len())
+		require.NoError(t, batch.Verify())
+
+		// a proof for other commitments fails only when the batch is verified
+		wrong := []*math.G1{commitments[1], commitments[0]}
+		verifier, batch = newVerifier(wrong)
+		require.NoError(t, verifier.Verify(rc))
+		require.ErrorIs(t, batch.Verify(), math2.ErrBatchVerificationFailed)
+	}
+}
@@ -472,6 +472,9 @@ type rangeVerifier struct {
 	// Provider creates a fresh Executor for each Prove call.
 	// If nil, DefaultProvider (SerialProvider) is used.
 	Provider executor.ExecutorProvider
+	// Batch, if not nil, collects the final group equations instead of checking them.
+	// The proof is then valid only if Batch.Verify succeeds.
+	Batch *math2.BatchVerifier
 }
 
 // NewRangeVerifier returns a rangeVerifier based on the passed arguments
@@ -573,7 +576,9 @@ func (v *rangeVerifier) Verify(rp *RangeProof) error {
 
 	comPrime := v.Commitment.Mul2(zSquare, v.CommitmentGenerators[0], polEval)
 
-	if !com.Equals(comPrime) {
+	if v.Batch != nil {
+		v.Batch.DeferEquality(com, comPrime)
+	} else if !com.Equals(comPrime) {
 		return errors.New("invalid range proof")
 	}
 
@@ -660,6 +665,7 @@ func (v *rangeVerifier) verifyIPA(rp *RangeProof, x *math.Zr, yPow []*math.Zr, z
 		v.Curve,
 		v.Provider,
 	)
+	ipv.Batch = v.Batch
 
 	return ipv.Verify(rp.IPA)
 }
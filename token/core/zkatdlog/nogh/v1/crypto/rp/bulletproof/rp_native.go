@@ -259,7 +259,9 @@ func nativeRPVerify[T any, E math2.GnarkFr[T]](v *rangeVerifier, rp *RangeProof,
 
 	comPrime := v.Commitment.Mul2(zSquare, v.CommitmentGenerators[0], polEval)
 
-	if !com.Equals(comPrime) {
+	if v.Batch != nil {
+		v.Batch.DeferEquality(com, comPrime)
+	} else if !com.Equals(comPrime) {
 		return errors.New("invalid range proof")
 	}
 
@@ -351,6 +353,7 @@ func nativeRPVerifyIPA[T any, E math2.GnarkFr[T]](v *rangeVerifier, rp *RangePro
 		v.Curve,
 		v.Provider,
 	)
+	ipv.Batch = v.Batch
 
 	return ipv.Verify(rp.IPA)
 }
@@ -0,0 +1,460 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package csp
+
+import (
+	"math/big"
+
+	mathlib "github.com/IBM/mathlib"
+	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/math"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+)
+
+// AggregatedTranscriptDomain separates the transcripts of aggregated range proofs from those of single range proofs
+const AggregatedTranscriptDomain = "CSP-AggregatedRangeProof-v1"
+
+// aggregatedRangeProver produces a single range proof for m committed values.
+// The bits of all the values are the evaluations of a single polynomial a(X) of degree m*NumberOfBits,
+// the bits of the j-th value at {j*n+1, ..., j*n+n}, so that the CSP proof runs over a witness of
+// length 2*m*NumberOfBits+4 and its size grows with log(m) instead of m.
+// The values are bound to the commitments through V* = \sum_j eta^{j+1} V_j,
+// where eta is drawn after the bits are committed.
+// The proof has the same structure as a RangeProof, its proof of knowledge refers to V*.
+type aggregatedRangeProver struct {
+	Commitments []*mathlib.G1 // commitments V_j to the values
+	values      []*mathlib.Zr // values v_j
+	r           []*mathlib.Zr // randomness r_j to mask the values
+
+	VGenerators  []*mathlib.G1  // two generators to commit to the values
+	AGenerators  []*mathlib.G1  // generators to commit to a(X), at least m*NumberOfBits+1
+	BGenerators  []*mathlib.G1  // generators to commit to b(X), at least m*NumberOfBits+1
+	NumberOfBits uint64         // number of bits n; each value must lie in [0, 2^n - 1]
+	Curve        *mathlib.Curve // curve
+
+	TranscriptHeader []byte
+}
+
+// NewAggregatedRangeProver returns a prover of a single range proof for the passed commitments
+func NewAggregatedRangeProver(
+	commitments []*mathlib.G1,
+	values []*mathlib.Zr,
+	r []*mathlib.Zr,
+	VGenerators []*mathlib.G1,
+	AGenerators []*mathlib.G1,
+	BGenerators []*mathlib.G1,
+	numberOfBits uint64,
+	curve *mathlib.Curve,
+) *aggregatedRangeProver {
+	return &aggregatedRangeProver{
+		Commitments:  commitments,
+		values:       values,
+		r:            r,
+		VGenerators:  VGenerators,
+		AGenerators:  AGenerators,
+		BGenerators:  BGenerators,
+		NumberOfBits: numberOfBits,
+		Curve:        curve,
+	}
+}
+
+func (rp *aggregatedRangeProver) WithTranscriptHeader(h []byte) *aggregatedRangeProver {
+	rp.TranscriptHeader = h
+
+	return rp
+}
+
+// Prove produces a RangeProof that shows that each committed value lies in [0, 2^NumberOfBits - 1]
+func (rp *aggregatedRangeProver) Prove() (*RangeProof, error) {
+	if err := validateAggregatedRangeProverInputs(rp.Curve, rp); err != nil {
+		return nil, errors.Wrap(err, "invalid aggregated range prover inputs")
+	}
+
+	n := rp.NumberOfBits
+	m := uint64(len(rp.Commitments))
+	bitCount := m * n
+	tr := aggregatedTranscript(rp.TranscriptHeader, rp.VGenerators, rp.AGenerators, rp.BGenerators, n, rp.Commitments, rp.Curve)
+
+	rand, err := rp.Curve.Rand()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize random number generator")
+	}
+
+	// Witness p = aCoeffs || bCoeffs where
+	//   aCoeffs = [a(0), a(1), ..., a(mn)]: a(jn+1), ..., a(jn+n) are the bits of v_j, a(0) is random
+	//   bCoeffs = [b(0), b(mn+1), ..., b(2mn)]: b(i) = a(i)*(a(i)-1)
+	aCoeffs := make([]*mathlib.Zr, bitCount+1)
+	aCoeffs[0] = rp.Curve.NewRandomZr(rand)
+	for j := range m {
+		bitsOfV, err := toBits(rp.values[j], n, rp.Curve)
+		if err != nil {
+			return nil, err
+		}
+		copy(aCoeffs[1+j*n:], bitsOfV)
+	}
+	aCoeffsExt, err := interpolate(bitCount, aCoeffs, rp.Curve)
+	if err != nil {
+		return nil, errors.New("Error while extending a polynomial")
+	}
+	bCoeffs := make([]*mathlib.Zr, bitCount+1)
+	b0 := rp.Curve.ModSub(aCoeffs[0], math.One(rp.Curve), rp.Curve.GroupOrder)
+	bCoeffs[0] = rp.Curve.ModMul(aCoeffs[0], b0, rp.Curve.GroupOrder)
+	for i := uint64(1); i <= bitCount; i++ {
+		ai := aCoeffsExt[bitCount+i]
+		aiMinus1 := rp.Curve.ModSub(ai, math.One(rp.Curve), rp.Curve.GroupOrder)
+		bCoeffs[i] = rp.Curve.ModMul(ai, aiMinus1, rp.Curve.GroupOrder)
+	}
+	p := make([]*mathlib.Zr, 2*bitCount+2)
+	g := make([]*mathlib.G1, 2*bitCount+2)
+	copy(p, aCoeffs)
+	copy(p[bitCount+1:], bCoeffs)
+	copy(g, rp.AGenerators[:bitCount+1])
+	copy(g[bitCount+1:], rp.BGenerators[:bitCount+1])
+
+	// First prover message: pComm = MSM(g, p). Absorb and squeeze eta, c.
+	pComm := rp.Curve.MultiScalarMul(g, p)
+	tr.Absorb(pComm.Bytes())
+	eta, err := tr.Squeeze()
+	if err != nil {
+		return nil, errors.New("Unable to obtain challenge eta")
+	}
+	c, err := tr.Squeeze()
+	if err != nil {
+		return nil, errors.New("Unable to obtain challenge c")
+	}
+
+	// V* = \sum_j eta^{j+1} V_j opens to v* = \sum_j eta^{j+1} v_j and r* = \sum_j eta^{j+1} r_j
+	weights := aggregationWeights(eta, m, rp.Curve)
+	vStar := math.InnerProduct(weights, rp.values, rp.Curve)
+	rStar := math.InnerProduct(weights, rp.r, rp.Curve)
+	commitment := rp.Curve.MultiScalarMul(rp.Commitments, weights)
+
+	// Schnorr proof of knowledge for V* = v*·G_v + r*·G_r.
+	pokTv := rp.Curve.NewRandomZr(rand)
+	pokTr := rp.Curve.NewRandomZr(rand)
+	pokA := rp.Curve.MultiScalarMul(rp.VGenerators, []*mathlib.Zr{pokTv, pokTr})
+	tr.Absorb(pokA.Bytes())
+	pokE, err := tr.Squeeze()
+	if err != nil {
+		return nil, errors.New("unable to obtain PoK challenge")
+	}
+	pokZv := rp.Curve.ModAddMul2(pokTv, math.One(rp.Curve), pokE, vStar, rp.Curve.GroupOrder)
+	pokZr := rp.Curve.ModAddMul2(pokTr, math.One(rp.Curve), pokE, rStar, rp.Curve.GroupOrder)
+
+	// u = a(c), absorb it, then squeeze gamma.
+	mu, err := getLagrangeMultipliers(bitCount, c, rp.Curve)
+	if err != nil {
+		return nil, errors.New("Unable to obtain lagrange multipliers")
+	}
+	nu, err := getLagrangeMultipliersPartial(bitCount, c, rp.Curve)
+	if err != nil {
+		return nil, errors.New("Unable to obtain partial lagrange multipliers")
+	}
+	u := math.InnerProduct(aCoeffs, mu, rp.Curve)
+	tr.Absorb(u.Bytes())
+	gamma, err := tr.Squeeze()
+	if err != nil {
+		return nil, errors.New("Unable to obtain challenge gamma")
+	}
+
+	// Extended witness pExt = aCoeffs || bCoeffs || v* || r* over gExt = g || VGenerators,
+	// committed by pCommExt = pComm + V*.
+	pCommExt := pComm.Copy()
+	pCommExt.Add(commitment)
+	pExt := make([]*mathlib.Zr, 2*bitCount+4)
+	gExt := make([]*mathlib.G1, 2*bitCount+4)
+	copy(pExt, p)
+	pExt[2*bitCount+2] = vStar
+	pExt[2*bitCount+3] = rStar
+	copy(gExt, g)
+	gExt[2*bitCount+2] = rp.VGenerators[0]
+	gExt[2*bitCount+3] = rp.VGenerators[1]
+	lf, lVal := aggregatedLinearForm(n, m, weights, gamma, mu, nu, u, rp.Curve)
+
+	// ZK blinding: random sBlind, commit it, evaluate L on it.
+	sBlind := make([]*mathlib.Zr, len(pExt))
+	for i := range sBlind {
+		sBlind[i] = rp.Curve.NewRandomZr(rand)
+	}
+	sComm := rp.Curve.MultiScalarMul(gExt, sBlind)
+	sVal := math.InnerProduct(lf, sBlind, rp.Curve)
+	tr.Absorb(sComm.Bytes())
+	tr.Absorb(sVal.Bytes())
+	rho, err := tr.Squeeze()
+	if err != nil {
+		return nil, errors.New("Unable to obtain challenge rho")
+	}
+
+	wit := make([]*mathlib.Zr, len(pExt))
+	for i := range pExt {
+		wit[i] = rp.Curve.ModAddMul2(pExt[i], math.One(rp.Curve), rho, sBlind[i], rp.Curve.GroupOrder)
+	}
+	witVal := rp.Curve.ModAddMul2(lVal, math.One(rp.Curve), rho, sVal, rp.Curve.GroupOrder)
+	witComm := pCommExt.Copy()
+	witComm.Add(sComm.Mul(rho))
+
+	// Pad witness / generators / linear form to the next power of 2 for CSP.
+	cspRounds, paddedSize := cspSize(uint64(len(wit)))
+	for uint64(len(wit)) < paddedSize {
+		wit = append(wit, math.Zero(rp.Curve))
+		gExt = append(gExt, rp.Curve.GenG1)
+		lf = append(lf, math.Zero(rp.Curve))
+	}
+
+	cspP := &prover{
+		Commitment:     witComm,
+		Generators:     gExt,
+		LinearForm:     lf,
+		Value:          witVal,
+		NumberOfRounds: cspRounds,
+		Curve:          rp.Curve,
+		witness:        wit,
+	}
+	cspProof, err := cspP.WithTranscriptHeader(tr.State()).Prove()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate CSP proof")
+	}
+
+	return &RangeProof{
+		pComm:    pComm,
+		pokV:     pokCommitment{A: pokA, Z: []*mathlib.Zr{pokZv, pokZr}},
+		u:        u,
+		sComm:    sComm,
+		sEval:    sVal,
+		cspProof: *cspProof,
+	}, nil
+}
+
+// aggregatedRangeVerifier checks a range proof produced by an aggregatedRangeProver
+type aggregatedRangeVerifier struct {
+	Commitments  []*mathlib.G1 // commitments V_j to the values
+	VGenerators  []*mathlib.G1 // generators for the value commitments
+	AGenerators  []*mathlib.G1 // generators for a(X), at least m*NumberOfBits+1
+	BGenerators  []*mathlib.G1 // generators for b(X), at least m*NumberOfBits+1
+	NumberOfBits uint64        // number of bits n; each value must lie in [0, 2^n - 1]
+	Curve        *mathlib.Curve
+
+	TranscriptHeader []byte
+	// Batch, if not nil, collects the final group equations instead of checking them.
+	// The proof is then valid only if Batch.Verify succeeds.
+	Batch *math.BatchVerifier
+}
+
+// NewAggregatedRangeVerifier returns a verifier of a single range proof for the passed commitments
+func NewAggregatedRangeVerifier(
+	commitments []*mathlib.G1,
+	VGenerators []*mathlib.G1,
+	AGenerators []*mathlib.G1,
+	BGenerators []*mathlib.G1,
+	numberOfBits uint64,
+	curve *mathlib.Curve,
+) *aggregatedRangeVerifier {
+	return &aggregatedRangeVerifier{
+		Commitments:  commitments,
+		VGenerators:  VGenerators,
+		AGenerators:  AGenerators,
+		BGenerators:  BGenerators,
+		NumberOfBits: numberOfBits,
+		Curve:        curve,
+	}
+}
+
+func (rv *aggregatedRangeVerifier) WithTranscriptHeader(h []byte) *aggregatedRangeVerifier {
+	rv.TranscriptHeader = h
+
+	return rv
+}
+
+// Verify checks that proof is a valid aggregated range proof for the commitments.
+// It mirrors the prover transcript, rebuilds the linear form and delegates the final check to the CSP verifier.
+func (rv *aggregatedRangeVerifier) Verify(proof *RangeProof) error {
+	if err := validateAggregatedRangeVerifierInputs(rv.Curve, rv); err != nil {
+		return errors.Wrap(err, "invalid aggregated range verifier inputs")
+	}
+	if err := validateRangeProof(rv.Curve, proof); err != nil {
+		return errors.Wrap(err, "invalid range proof structure")
+	}
+
+	n := rv.NumberOfBits
+	m := uint64(len(rv.Commitments))
+	bitCount := m * n
+	tr := aggregatedTranscript(rv.TranscriptHeader, rv.VGenerators, rv.AGenerators, rv.BGenerators, n, rv.Commitments, rv.Curve)
+
+	tr.Absorb(proof.pComm.Bytes())
+	eta, err := tr.Squeeze()
+	if err != nil {
+		return errors.New("unable to recompute challenge eta")
+	}
+	c, err := tr.Squeeze()
+	if err != nil {
+		return errors.New("unable to recompute challenge c")
+	}
+	weights := aggregationWeights(eta, m, rv.Curve)
+	commitment := rv.Curve.MultiScalarMul(rv.Commitments, weights)
+
+	// Verify the Schnorr PoK for V* = v*·G_v + r*·G_r: z_v·G_v + z_r·G_r == pokA + e·V*
+	tr.Absorb(proof.pokV.A.Bytes())
+	pokE, err := tr.Squeeze()
+	if err != nil {
+		return errors.New("unable to recompute PoK challenge")
+	}
+	if rv.Batch != nil {
+		// postpone the check z_v·G_v + z_r·G_r - pokA - e·V* == 0
+		minusOne := rv.Curve.NewZrFromInt(1)
+		minusOne.Neg()
+		minusE := pokE.Copy()
+		minusE.Neg()
+		points := append(append([]*mathlib.G1{}, rv.VGenerators...), proof.pokV.A, commitment)
+		scalars := append(append([]*mathlib.Zr{}, proof.pokV.Z...), minusOne, minusE)
+		rv.Batch.Defer(points, scalars)
+	} else {
+		pokLHS := rv.Curve.MultiScalarMul(rv.VGenerators, proof.pokV.Z)
+		pokRHS := proof.pokV.A.Copy()
+		pokRHS.Add(commitment.Mul(pokE))
+		if !pokLHS.Equals(pokRHS) {
+			return errors.New("proof of knowledge for value commitment failed")
+		}
+	}
+
+	mu, err := getLagrangeMultipliers(bitCount, c, rv.Curve)
+	if err != nil {
+		return errors.New("unable to obtain lagrange multipliers")
+	}
+	nu, err := getLagrangeMultipliersPartial(bitCount, c, rv.Curve)
+	if err != nil {
+		return errors.New("unable to obtain partial lagrange multipliers")
+	}
+	tr.Absorb(proof.u.Bytes())
+	gamma, err := tr.Squeeze()
+	if err != nil {
+		return errors.New("unable to recompute challenge gamma")
+	}
+
+	// pCommExt = pComm + V* over gExt = AGenerators || BGenerators || VGenerators
+	pCommExt := proof.pComm.Copy()
+	pCommExt.Add(commitment)
+	gExt := make([]*mathlib.G1, 2*bitCount+4)
+	copy(gExt, rv.AGenerators[:bitCount+1])
+	copy(gExt[bitCount+1:], rv.BGenerators[:bitCount+1])
+	gExt[2*bitCount+2] = rv.VGenerators[0]
+	gExt[2*bitCount+3] = rv.VGenerators[1]
+	lf, lVal := aggregatedLinearForm(n, m, weights, gamma, mu, nu, proof.u, rv.Curve)
+
+	tr.Absorb(proof.sComm.Bytes())
+	tr.Absorb(proof.sEval.Bytes())
+	rho, err := tr.Squeeze()
+	if err != nil {
+		return errors.New("unable to recompute challenge rho")
+	}
+	witComm := pCommExt.Copy()
+	witComm.Add(proof.sComm.Mul(rho))
+	witVal := rv.Curve.ModAddMul2(lVal, math.One(rv.Curve), rho, proof.sEval, rv.Curve.GroupOrder)
+
+	cspRounds, paddedSize := cspSize(uint64(len(gExt)))
+	for uint64(len(gExt)) < paddedSize {
+		gExt = append(gExt, rv.Curve.GenG1)
+		lf = append(lf, math.Zero(rv.Curve))
+	}
+
+	cspV := &verifier{
+		Commitment:     witComm,
+		Generators:     gExt,
+		LinearForm:     lf,
+		Value:          witVal,
+		NumberOfRounds: cspRounds,
+		Curve:          rv.Curve,
+		Batch:          rv.Batch,
+	}
+
+	return cspV.WithTranscriptHeader(tr.State()).Verify(&proof.cspProof)
+}
+
+// aggregatedTranscript returns the transcript of an aggregated range proof after the public statement is absorbed
+func aggregatedTranscript(header []byte, vGenerators, aGenerators, bGenerators []*mathlib.G1, n uint64, commitments []*mathlib.G1, curve *mathlib.Curve) *Transcript {
+	tr := &Transcript{Curve: curve}
+	if len(header) != 0 {
+		tr.SetState(header)
+	} else {
+		tr.InitHasherWithDomain(AggregatedTranscriptDomain)
+		for _, g := range vGenerators {
+			tr.Absorb(g.Bytes())
+		}
+		for _, g := range aGenerators {
+			tr.Absorb(g.Bytes())
+		}
+		for _, g := range bGenerators {
+			tr.Absorb(g.Bytes())
+		}
+		tr.Absorb(new(big.Int).SetUint64(n).Bytes())
+	}
+	tr.Absorb(new(big.Int).SetUint64(uint64(len(commitments))).Bytes())
+	for _, com := range commitments {
+		tr.Absorb(com.Bytes())
+	}
+
+	return tr
+}
+
+// aggregationWeights returns (eta, eta^2, ..., eta^m)
+func aggregationWeights(eta *mathlib.Zr, m uint64, curve *mathlib.Curve) []*mathlib.Zr {
+	weights := make([]*mathlib.Zr, m)
+	weights[0] = eta.Copy()
+	for j := uint64(1); j < m; j++ {
+		weights[j] = curve.ModMul(weights[j-1], eta, curve.GroupOrder)
+	}
+
+	return weights
+}
+
+// aggregatedLinearForm returns the linear form L = L1 + gamma*L2 + gamma^2*L3 over aCoeffs || bCoeffs || v* || r*
+// and its claimed value gamma*u + gamma^2*u*(u-1), where
+//
+//	L1 = \sum_j eta^{j+1} \sum_k 2^k a(jn+k+1) - v*  (zero for an honest prover)
+//	L2 = a(c) = <mu, aCoeffs>
+//	L3 = b(c) = <nu, bCoeffs>
+func aggregatedLinearForm(n, m uint64, weights []*mathlib.Zr, gamma *mathlib.Zr, mu, nu []*mathlib.Zr, u *mathlib.Zr, curve *mathlib.Curve) ([]*mathlib.Zr, *mathlib.Zr) {
+	bitCount := m * n
+	gammaSquare := curve.ModMul(gamma, gamma, curve.GroupOrder)
+	lf := make([]*mathlib.Zr, 2*bitCount+4)
+	for i := range lf {
+		lf[i] = math.Zero(curve)
+	}
+	for j := range m {
+		for k := range n {
+			lf[1+j*n+k] = curve.ModMul(weights[j], math.PowerOfTwo(curve, k), curve.GroupOrder)
+		}
+	}
+	minusOne := curve.NewZrFromInt(1)
+	minusOne.Neg()
+	lf[2*bitCount+2] = minusOne
+	for i := uint64(0); i <= bitCount; i++ {
+		lf[i] = curve.ModAddMul2(lf[i], math.One(curve), gamma, mu[i], curve.GroupOrder)
+	}
+	for k := uint64(0); k <= bitCount; k++ {
+		lf[bitCount+1+k] = curve.ModMul(gammaSquare, nu[k], curve.GroupOrder)
+	}
+
+	uMinus1 := curve.ModSub(u, math.One(curve), curve.GroupOrder)
+	lVal := curve.ModAddMul2(
+		gamma, u,
+		gammaSquare, curve.ModMul(u, uMinus1, curve.GroupOrder),
+		curve.GroupOrder,
+	)
+
+	return lf, lVal
+}
+
+// cspSize returns the number of rounds of a CSP proof over a witness of the passed size, and the padded size
+func cspSize(size uint64) (uint64, uint64) {
+	rounds := uint64(0)
+	paddedSize := uint64(1)
+	for paddedSize < size {
+		paddedSize <<= 1
+		rounds++
+	}
+
+	return rounds, paddedSize
+}
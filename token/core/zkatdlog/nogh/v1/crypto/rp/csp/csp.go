@@ -181,6 +181,9 @@ type verifier struct {
 	NumberOfRounds   uint64        // log₂(vector length)
 	Curve            *mathlib.Curve
 	TranscriptHeader []byte
+	// Batch, if not nil, collects the final group equations instead of checking them.
+	// The proof is then valid only if Batch.Verify succeeds.
+	Batch *math.BatchVerifier
 }
 
 func (v *verifier) WithTranscriptHeader(h []byte) *verifier {
@@ -295,20 +298,39 @@ func (v *verifier) Verify(proof *Proof) error {
 		comScalars = append(comScalars, mulScratch.Copy())
 	}
 
-	com := v.Curve.MultiScalarMul(comPoints, comScalars)
-
 	// Compute the coefficient vector s such that
 	//   gen_f = sum_i s[i] · gen[i]   and   f_f = sum_i s[i] · f[i]
 	// then evaluate both via a single MSM and a single inner product.
 	n := 1 << v.NumberOfRounds
 	s := sVector(n, challenges, v.Curve)
 
-	// gen_f = MSM(Generators, s)
-	genF := v.Curve.MultiScalarMul(v.Generators, s)
-
 	// f_f = ⟨s, LinearForm⟩  (scalar-field MSM via ModAddMul)
 	fF := math.InnerProduct(s, v.LinearForm, v.Curve)
 
+	if v.Batch != nil {
+		// postpone the final check as MSM(comPoints, f_f·comScalars) - MSM(Generators, val_f·s) == 0
+		points := make([]*mathlib.G1, 0, len(comPoints)+len(v.Generators))
+		scalars := make([]*mathlib.Zr, 0, len(comPoints)+len(v.Generators))
+		points = append(points, comPoints...)
+		for _, cs := range comScalars {
+			scalars = append(scalars, v.Curve.ModMul(cs, fF, v.Curve.GroupOrder))
+		}
+		minusVal := val.Copy()
+		minusVal.Neg()
+		points = append(points, v.Generators...)
+		for _, si := range s {
+			scalars = append(scalars, v.Curve.ModMul(si, minusVal, v.Curve.GroupOrder))
+		}
+		v.Batch.Defer(points, scalars)
+
+		return nil
+	}
+
+	com := v.Curve.MultiScalarMul(comPoints, comScalars)
+
+	// gen_f = MSM(Generators, s)
+	genF := v.Curve.MultiScalarMul(v.Generators, s)
+
 	// Final check: com_f^{f_f} == gen_f^{val_f}
 	lhs := com.Mul(fF)
 	rhs := genF.Mul(val)
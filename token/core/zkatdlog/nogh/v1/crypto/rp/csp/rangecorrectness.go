@@ -84,6 +84,9 @@ type RangeCorrectnessProver struct {
 	// Provider creates a fresh Executor for each Prove call.
 	// If nil, executor.DefaultProvider (SerialProvider) is used.
 	Provider executor.ExecutorProvider
+	// MaxAggregation is the maximum number of commitments covered by a single aggregated proof.
+	// If zero, one proof is generated for each commitment.
+	MaxAggregation uint64
 }
 
 // NewRangeCorrectnessProver returns a new RangeCorrectnessProver instance.
@@ -115,12 +118,34 @@ func NewRangeCorrectnessProver(
 	}
 }
 
-// Prove generates a set of range proofs, one per commitment.
+// NewAggregatedRangeCorrectnessProver returns a new RangeCorrectnessProver that proves
+// the commitments in groups of at most maxAggregation, with one aggregated proof per group.
+// The generators must contain bitLength*maxAggregation+1 elements.
+func NewAggregatedRangeCorrectnessProver(
+	coms []*math.G1,
+	values []uint64,
+	blindingFactors []*math.Zr,
+	pedersenParameters, leftGenerators, rightGenerators []*math.G1,
+	bitLength, maxAggregation uint64,
+	c *math.Curve,
+	provider executor.ExecutorProvider,
+) *RangeCorrectnessProver {
+	p := NewRangeCorrectnessProver(coms, values, blindingFactors, pedersenParameters, leftGenerators, rightGenerators, bitLength, c, provider)
+	p.MaxAggregation = maxAggregation
+
+	return p
+}
+
+// Prove generates a set of range proofs, one per commitment, or one per group of
+// at most MaxAggregation commitments if MaxAggregation is set.
 // Independent proofs are executed using the Provider's Executor strategy.
 func (p *RangeCorrectnessProver) Prove() (*RangeCorrectness, error) {
 	if len(p.TranscriptHeader) == 0 {
 		return nil, errors.New("transcript header is empty")
 	}
+	if p.MaxAggregation > 0 {
+		return p.proveAggregated()
+	}
 
 	n := len(p.Commitments)
 	rc := &RangeCorrectness{
@@ -183,6 +208,12 @@ type RangeCorrectnessVerifier struct {
 	// Provider creates a fresh Executor for each Verify call.
 	// If nil, executor.DefaultProvider (SerialProvider) is used.
 	Provider executor.ExecutorProvider
+	// MaxAggregation is the maximum number of commitments covered by a single aggregated proof.
+	// If zero, one proof is expected for each commitment.
+	MaxAggregation uint64
+	// Batch, if not nil, collects the final group equations instead of checking them.
+	// The proof is then valid only if Batch.Verify succeeds.
+	Batch *math2.BatchVerifier
 }
 
 // NewRangeCorrectnessVerifier returns a new RangeCorrectnessVerifier instance.
@@ -208,15 +239,32 @@ func NewRangeCorrectnessVerifier(
 	}
 }
 
+// NewAggregatedRangeCorrectnessVerifier returns a new RangeCorrectnessVerifier that expects
+// one aggregated proof for each group of at most maxAggregation commitments.
+func NewAggregatedRangeCorrectnessVerifier(
+	pedersenParameters, leftGenerators, rightGenerators []*math.G1,
+	bitLength, maxAggregation uint64,
+	curve *math.Curve,
+	provider executor.ExecutorProvider,
+) *RangeCorrectnessVerifier {
+	v := NewRangeCorrectnessVerifier(pedersenParameters, leftGenerators, rightGenerators, bitLength, curve, provider)
+	v.MaxAggregation = maxAggregation
+
+	return v
+}
+
 // Verify checks if the provided set of range proofs is valid.
 // Independent proofs are verified using the Provider's Executor strategy.
 func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
-	if len(rc.Proofs) != len(v.Commitments) {
-		return errors.New("invalid range proof")
-	}
 	if len(v.TranscriptHeader) == 0 {
 		return errors.New("transcript header is empty")
 	}
+	if v.MaxAggregation > 0 {
+		return v.verifyAggregated(rc)
+	}
+	if len(rc.Proofs) != len(v.Commitments) {
+		return errors.New("invalid range proof")
+	}
 
 	n := len(rc.Proofs)
 	errs := make([]error, n)
@@ -241,6 +289,7 @@ func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
 				v.BitLength,
 				v.Curve,
 			).WithTranscriptHeader(v.TranscriptHeader)
+			bv.Batch = v.Batch
 
 			errs[i] = bv.Verify(rc.Proofs[i])
 		})
@@ -262,3 +311,96 @@ func (v *RangeCorrectnessVerifier) WithTranscriptHeader(header []byte) *RangeCor
 
 	return v
 }
+
+// proveAggregated generates one aggregated range proof for each group of at most MaxAggregation commitments
+func (p *RangeCorrectnessProver) proveAggregated() (*RangeCorrectness, error) {
+	groups := aggregationGroups(len(p.Commitments), p.MaxAggregation)
+	rc := &RangeCorrectness{
+		Proofs: make([]*RangeProof, len(groups)),
+	}
+
+	exec := p.Provider.New()
+	errs := make([]error, len(groups))
+	for i, g := range groups {
+		exec.Submit(func() {
+			values := make([]*math.Zr, g.end-g.start)
+			for j := range values {
+				values[j] = math2.NewCachedZrFromInt(p.Curve, p.Values[g.start+j])
+			}
+			rc.Proofs[i], errs[i] = NewAggregatedRangeProver(
+				p.Commitments[g.start:g.end],
+				values,
+				p.BlindingFactors[g.start:g.end],
+				p.PedersenParameters,
+				p.LeftGenerators,
+				p.RightGenerators,
+				p.BitLength,
+				p.Curve,
+			).WithTranscriptHeader(p.TranscriptHeader).Prove()
+		})
+	}
+	exec.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rc, nil
+}
+
+// verifyAggregated checks that each group of at most MaxAggregation commitments has a valid aggregated range proof
+func (v *RangeCorrectnessVerifier) verifyAggregated(rc *RangeCorrectness) error {
+	groups := aggregationGroups(len(v.Commitments), v.MaxAggregation)
+	if len(rc.Proofs) != len(groups) {
+		return errors.New("invalid range proof")
+	}
+
+	exec := v.Provider.New()
+	errs := make([]error, len(groups))
+	for i, g := range groups {
+		exec.Submit(func() {
+			if rc.Proofs[i] == nil {
+				errs[i] = errors.Errorf("invalid range proof: nil proof at index %d", i)
+
+				return
+			}
+			av := NewAggregatedRangeVerifier(
+				v.Commitments[g.start:g.end],
+				v.PedersenParameters,
+				v.LeftGenerators,
+				v.RightGenerators,
+				v.BitLength,
+				v.Curve,
+			).WithTranscriptHeader(v.TranscriptHeader)
+			av.Batch = v.Batch
+			errs[i] = av.Verify(rc.Proofs[i])
+		})
+	}
+	exec.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return errors.Wrapf(err, "invalid range proof at index %d", i)
+		}
+	}
+
+	return nil
+}
+
+// aggregationGroup is the range [start, end) of the commitments covered by an aggregated proof
+type aggregationGroup struct {
+	start, end int
+}
+
+// aggregationGroups splits n commitments in consecutive groups of at most maxAggregation commitments
+func aggregationGroups(n int, maxAggregation uint64) []aggregationGroup {
+	size := int(maxAggregation) // #nosec G115
+	groups := make([]aggregationGroup, 0, (n+size-1)/size)
+	for start := 0; start < n; start += size {
+		groups = append(groups, aggregationGroup{start: start, end: min(start+size, n)})
+	}
+
+	return groups
+}
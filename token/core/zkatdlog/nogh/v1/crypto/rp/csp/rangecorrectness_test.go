@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	math "github.com/IBM/mathlib"
+	math2 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/math"
 	"github.com/stretchr/testify/require"
 )
 
@@ -695,3 +696,58 @@ func TestCSPRangeCorrectnessDeserializeInvalid(t *testing.T) {
 		})
 	}
 }
+
+// TestCSPRangeCorrectnessBatch verifies range proofs with deferred group checks.
+// Given valid range proofs,
+// When they are verified with a batch verifier,
+// Then the batch should succeed for the right commitments and fail for the wrong ones.
+func TestCSPRangeCorrectnessBatch(t *testing.T) {
+	curve := math.Curves[math.BN254]
+	rand, err := curve.Rand()
+	require.NoError(t, err)
+
+	n := uint64(8)
+	pedersenParams := []*math.G1{
+		curve.HashToG1([]byte("ped-0")),
+		curve.HashToG1([]byte("ped-1")),
+	}
+	leftGens := make([]*math.G1, n+1)
+	rightGens := make([]*math.G1, n+1)
+	for i := uint64(0); i <= n; i++ {
+		leftGens[i] = curve.HashToG1([]byte{byte(i), 0})
+		rightGens[i] = curve.HashToG1([]byte{byte(i), 1})
+	}
+
+	values := []uint64{3, 200}
+	commitments := make([]*math.G1, len(values))
+	blindingFactors := make([]*math.Zr, len(values))
+	for i := range values {
+		blindingFactors[i] = curve.NewRandomZr(rand)
+		commitments[i] = curve.MultiScalarMul(pedersenParams, []*math.Zr{curve.NewZrFromUint64(values[i]), blindingFactors[i]})
+	}
+
+	rc, err := NewRangeCorrectnessProver(commitments, values, blindingFactors, pedersenParams, leftGens, rightGens, n, curve, nil).
+		WithTranscriptHeader([]byte("a_transcript_header")).
+		Prove()
+	require.NoError(t, err)
+
+	newVerifier := func(commitments []*math.G1) (*RangeCorrectnessVerifier, *math2.BatchVerifier) {
+		batch, err := math2.NewBatchVerifier(curve)
+		require.NoError(t, err)
+		verifier := NewRangeCorrectnessVerifier(pedersenParams, leftGens, rightGens, n, curve, nil).
+			WithTranscriptHeader([]byte("a_transcript_header"))
+		verifier.Commitments = commitments
+		verifier.Batch = batch
+
+		return verifier, batch
+	}
+
+	verifier, batch := newVerifier(commitments)
+	require.NoError(t, verifier.Verify(rc))
+	require.Positive(t, batch.Len())
+	require.NoError(t, batch.Verify())
+
+	verifier, batch = newVerifier([]*math.G1{commitments[1], commitments[0]})
+	require.NoError(t, verifier.Verify(rc))
+	require.ErrorIs(t, batch.Verify(), math2.ErrBatchVerificationFailed)
+}
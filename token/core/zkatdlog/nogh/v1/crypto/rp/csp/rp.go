@@ -455,6 +455,9 @@ type rangeVerifier struct {
 	Curve        *mathlib.Curve
 
 	TranscriptHeader []byte
+	// Batch, if not nil, collects the final group equations instead of checking them.
+	// The proof is then valid only if Batch.Verify succeeds.
+	Batch *math.BatchVerifier
 }
 
 func NewRangeVerifier(VGenerators []*mathlib.G1, AGenerators []*mathlib.G1, BGenerators []*mathlib.G1, VCommitment *mathlib.G1, numberOfBits uint64, curve *mathlib.Curve) *rangeVerifier {
@@ -509,11 +512,22 @@ func (rv *rangeVerifier) Verify(proof *RangeProof) error {
 	if err != nil {
 		return errors.New("unable to recompute PoK challenge")
 	}
-	pokLHS := rv.Curve.MultiScalarMul(rv.VGenerators, proof.pokV.Z)
-	pokRHS := proof.pokV.A.Copy()
-	pokRHS.Add(rv.VCommitment.Mul(pokE))
-	if !pokLHS.Equals(pokRHS) {
-		return errors.New("proof of knowledge for value commitment failed")
+	if rv.Batch != nil {
+		// postpone the check z_v·G_v + z_r·G_r - pokA - e·V == 0
+		minusOne := rv.Curve.NewZrFromInt(1)
+		minusOne.Neg()
+		minusE := pokE.Copy()
+		minusE.Neg()
+		points := append(append([]*mathlib.G1{}, rv.VGenerators...), proof.pokV.A, rv.VCommitment)
+		scalars := append(append([]*mathlib.Zr{}, proof.pokV.Z...), minusOne, minusE)
+		rv.Batch.Defer(points, scalars)
+	} else {
+		pokLHS := rv.Curve.MultiScalarMul(rv.VGenerators, proof.pokV.Z)
+		pokRHS := proof.pokV.A.Copy()
+		pokRHS.Add(rv.VCommitment.Mul(pokE))
+		if !pokLHS.Equals(pokRHS) {
+			return errors.New("proof of knowledge for value commitment failed")
+		}
 	}
 
 	tr.Absorb(proof.pComm.Bytes())
@@ -625,6 +639,7 @@ func (rv *rangeVerifier) Verify(proof *RangeProof) error {
 		Value:          witVal,
 		NumberOfRounds: cspRounds,
 		Curve:          rv.Curve,
+		Batch:          rv.Batch,
 	}
 
 	return cspV.WithTranscriptHeader(rv.TranscriptHeader).Verify(&proof.cspProof)
@@ -258,3 +258,63 @@ func validateRangeProof(curve *mathlib.Curve, proof *RangeProof) error {
 
 	return validateCSPProof(curve, &proof.cspProof, uint64(len(proof.cspProof.Left)))
 }
+
+// validateAggregatedRangeProverInputs validates all inputs for the aggregated range proof prover.
+func validateAggregatedRangeProverInputs(curve *mathlib.Curve, p *aggregatedRangeProver) error {
+	if err := validateCurve(curve); err != nil {
+		return errors.Wrapf(err, "invalid curve")
+	}
+	if len(p.Commitments) == 0 {
+		return errors.Wrapf(ErrInvalidLength, "commitments cannot be empty")
+	}
+	if err := validateG1Slice("commitments", p.Commitments, curve, 0); err != nil {
+		return err
+	}
+	if err := validateZrSlice("values", p.values, curve, len(p.Commitments)); err != nil {
+		return err
+	}
+	if err := validateZrSlice("randomness", p.r, curve, len(p.Commitments)); err != nil {
+		return err
+	}
+
+	return validateAggregatedGenerators(curve, p.VGenerators, p.AGenerators, p.BGenerators, p.NumberOfBits, uint64(len(p.Commitments)))
+}
+
+// validateAggregatedRangeVerifierInputs validates all inputs for the aggregated range proof verifier.
+func validateAggregatedRangeVerifierInputs(curve *mathlib.Curve, v *aggregatedRangeVerifier) error {
+	if err := validateCurve(curve); err != nil {
+		return errors.Wrapf(err, "invalid curve")
+	}
+	if len(v.Commitments) == 0 {
+		return errors.Wrapf(ErrInvalidLength, "commitments cannot be empty")
+	}
+	if err := validateG1Slice("commitments", v.Commitments, curve, 0); err != nil {
+		return err
+	}
+
+	return validateAggregatedGenerators(curve, v.VGenerators, v.AGenerators, v.BGenerators, v.NumberOfBits, uint64(len(v.Commitments)))
+}
+
+// validateAggregatedGenerators checks that there are enough generators to aggregate m values of numberOfBits bits.
+func validateAggregatedGenerators(curve *mathlib.Curve, vGenerators, aGenerators, bGenerators []*mathlib.G1, numberOfBits, m uint64) error {
+	if numberOfBits == 0 {
+		return errors.Wrapf(ErrInvalidBitCount, "must be greater than 0")
+	}
+	if numberOfBits > 64 {
+		return errors.Wrapf(ErrInvalidBitCount, "cannot exceed 64")
+	}
+	if err := validateG1Slice("VGenerators", vGenerators, curve, 2); err != nil {
+		return err
+	}
+	if err := validateG1Slice("AGenerators", aGenerators, curve, 0); err != nil {
+		return err
+	}
+	if err := validateG1Slice("BGenerators", bGenerators, curve, len(aGenerators)); err != nil {
+		return err
+	}
+	if uint64(len(aGenerators)) < m*numberOfBits+1 {
+		return errors.Wrapf(ErrInvalidLength, "not enough generators to aggregate %d values of %d bits, got %d", m, numberOfBits, len(aGenerators))
+	}
+
+	return nil
+}
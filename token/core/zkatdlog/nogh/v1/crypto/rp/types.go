@@ -12,4 +12,10 @@ const (
 	_ ProofType = iota
 	RangeProofType
 	CSPRangeProofType
+	// AggregatedRangeProofType proves the values of up to a given number of commitments
+	// with a single Bulletproofs range proof of logarithmic size.
+	AggregatedRangeProofType
+	// AggregatedCSPRangeProofType proves the values of up to a given number of commitments
+	// with a single CSP-based range proof of logarithmic size.
+	AggregatedCSPRangeProofType
 )
@@ -13,11 +13,17 @@ import (
 	"github.com/LFDT-Panurus/panurus/token/driver"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/boolpolicy"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/deserializer"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/hd"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/hybrid"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix/revocation"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/idemixnym"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/interop/htlc"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/multisig"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/sigscheme"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/threshold"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/x509"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/x509/crl"
 	htlc2 "github.com/LFDT-Panurus/panurus/token/services/interop/htlc"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 )
@@ -39,12 +45,31 @@ func NewDeserializer(pp *v1.PublicParams) (*Deserializer, error) {
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed getting idemix deserializer for passed public params [%d]", idemixIssuerPublicKey.Curve)
 		}
-		des.AddTypedVerifierDeserializer(idemix.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(idemixDes, idemixDes))
+		revocationVerifier, err := revocation.NewVerifierFromExtras(pp.Extras(), idemixIssuerPublicKey.PublicKey, idemixIssuerPublicKey.Curve)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to load idemix revocation list")
+		}
+		// plain idemix signatures cannot carry non-revocation proofs,
+		// therefore they are not accepted once some credential of the issuer has been revoked
+		if !revocationVerifier.Enforced() {
+			des.AddTypedVerifierDeserializer(idemix.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(idemixDes, idemixDes))
+		}
 
-		idemixNymDes := idemixnym.NewDeserializer(idemixDes)
+		idemixNymDes := idemixnym.NewDeserializerWithRevocation(idemixDes, revocationVerifier)
 		des.AddTypedVerifierDeserializer(idemixnym.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(idemixNymDes, idemixNymDes))
 	}
-	des.AddTypedVerifierDeserializer(x509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&x509.IdentityDeserializer{}, &x509.AuditMatcherDeserializer{}))
+	checker, err := crl.NewCheckerFromExtras(pp.Extras())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to load certificate revocation lists")
+	}
+	des.AddTypedVerifierDeserializer(x509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(crl.NewDeserializer(&x509.IdentityDeserializer{}, checker, nil), &x509.AuditMatcherDeserializer{}))
+	for _, identityType := range sigscheme.IdentityTypes() {
+		des.AddTypedVerifierDeserializer(identityType, deserializer.NewTypedIdentityVerifierDeserializer(crl.NewDeserializer(sigscheme.NewIdentityDeserializer(identityType), checker, nil), &x509.AuditMatcherDeserializer{}))
+	}
+	des.AddTypedVerifierDeserializer(hybrid.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(crl.NewDeserializer(&hybrid.IdentityDeserializer{}, checker, hybrid.Certificate), &hybrid.AuditMatcherDeserializer{}))
+	// derived keys carry no certificate, hence they are not checked against the certificate revocation lists
+	des.AddTypedVerifierDeserializer(hd.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&hd.IdentityDeserializer{}, &hd.AuditMatcherDeserializer{}))
+	des.AddTypedVerifierDeserializer(threshold.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&threshold.IdentityDeserializer{}, &threshold.AuditMatcherDeserializer{}))
 	des.AddTypedVerifierDeserializer(htlc2.ScriptType, htlc.NewTypedIdentityDeserializer(des))
 	des.AddTypedVerifierDeserializer(multisig.Multisig, multisig.NewTypedIdentityDeserializer(des, des))
 	des.AddTypedVerifierDeserializer(boolpolicy.Policy, boolpolicy.NewTypedIdentityDeserializer(des, des))
@@ -92,6 +117,11 @@ func NewEIDRHDeserializer() *EIDRHDeserializer {
 	d.AddDeserializer(idemix.IdentityType, &idemix.AuditInfoDeserializer{})
 	d.AddDeserializer(idemixnym.IdentityType, &idemixnym.AuditInfoDeserializer{})
 	d.AddDeserializer(x509.IdentityType, &x509.AuditInfoDeserializer{})
+	d.AddDeserializer(hybrid.IdentityType, &x509.AuditInfoDeserializer{})
+	d.AddDeserializer(hd.IdentityType, &hd.AuditInfoDeserializer{})
+	for _, identityType := range sigscheme.IdentityTypes() {
+		d.AddDeserializer(identityType, &x509.AuditInfoDeserializer{})
+	}
 	d.AddDeserializer(htlc2.ScriptType, htlc.NewAuditDeserializer(d))
 	d.AddDeserializer(multisig.Multisig, &multisig.AuditInfoDeserializer{})
 	d.AddDeserializer(boolpolicy.Policy, &boolpolicy.AuditInfoDeserializer{})
@@ -17,6 +17,7 @@ import (
 	v1token "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/token"
 	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/validator"
 	"github.com/LFDT-Panurus/panurus/token/driver"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/threshold"
 	"github.com/LFDT-Panurus/panurus/token/services/interop/htlc"
 	"github.com/LFDT-Panurus/panurus/token/services/logging"
 	"github.com/LFDT-Panurus/panurus/token/services/ttx/boolpolicy"
@@ -48,6 +49,7 @@ func NewTokenDriver(
 	endpointService cdriver.NetworkBinderService,
 	networkProvider cdriver.NetworkProvider,
 	vaultProvider cdriver.VaultProvider,
+	coordinators *threshold.Coordinators,
 ) core.NamedFactory[driver.Driver] {
 	return core.NamedFactory[driver.Driver]{
 		Name: core.DriverIdentifier(v1setup.DLogNoGHDriverName, v1setup.ProtocolV1),
@@ -60,6 +62,7 @@ func NewTokenDriver(
 			endpointService,
 			networkProvider,
 			vaultProvider,
+			coordinators,
 		),
 	}
 }
@@ -73,16 +76,18 @@ func newTokenDriver(
 	endpointService cdriver.NetworkBinderService,
 	networkProvider cdriver.NetworkProvider,
 	vaultProvider cdriver.VaultProvider,
+	coordinators *threshold.Coordinators,
 ) *Driver {
 	return &Driver{
-		metricsProvider:  metricsProvider,
-		tracerProvider:   tracerProvider,
-		configService:    configService,
-		storageProvider:  storageProvider,
-		identityProvider: identityProvider,
-		endpointService:  endpointService,
-		networkProvider:  networkProvider,
-		vaultProvider:    vaultProvider,
+		BaseWalletServiceFactory: BaseWalletServiceFactory{Coordinators: coordinators},
+		metricsProvider:          metricsProvider,
+		tracerProvider:           tracerProvider,
+		configService:            configService,
+		storageProvider:          storageProvider,
+		identityProvider:         identityProvider,
+		endpointService:          endpointService,
+		networkProvider:          networkProvider,
+		vaultProvider:            vaultProvider,
 	}
 }
 
@@ -7,11 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package driver_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/IBM/idemix/bccsp/types"
 	math3 "github.com/IBM/mathlib"
 	"github.com/LFDT-Panurus/panurus/token/core"
 	mock2 "github.com/LFDT-Panurus/panurus/token/core/common/driver/mock"
@@ -20,10 +24,18 @@ import (
 	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/setup"
 	tdriver "github.com/LFDT-Panurus/panurus/token/driver"
 	dmock "github.com/LFDT-Panurus/panurus/token/driver/mock"
+	"github.com/LFDT-Panurus/panurus/token/services/identity"
 	imock "github.com/LFDT-Panurus/panurus/token/services/identity/driver/mock"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix/crypto"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix/revocation"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemixnym"
+	idemixnymmock "github.com/LFDT-Panurus/panurus/token/services/identity/idemixnym/mock"
 	idmock "github.com/LFDT-Panurus/panurus/token/services/identity/mock"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/threshold"
 	"github.com/LFDT-Panurus/panurus/token/services/network"
 	"github.com/LFDT-Panurus/panurus/token/services/storage"
+	kvs2 "github.com/LFDT-Panurus/panurus/token/services/storage/db/kvs"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/metrics/disabled"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,6 +72,7 @@ func TestNewDriver(t *testing.T) {
 		endpointService,
 		networkProvider,
 		vaultProvider,
+		threshold.NewCoordinators(),
 	)
 
 	assert.NotNil(t, factory.Driver)
@@ -85,6 +98,7 @@ func TestNewTokenService(t *testing.T) {
 		endpointService,
 		networkProvider,
 		vaultProvider,
+		threshold.NewCoordinators(),
 	).Driver.(*driver.Driver)
 
 	tmsID := tdriver.TMSID{Network: "n1", Channel: "c1", Namespace: "ns1"}
@@ -304,3 +318,80 @@ func TestDeserializers(t *testing.T) {
 	eidrh := driver.NewEIDRHDeserializer()
 	assert.NotNil(t, eidrh)
 }
+
+func TestDeserializerRevocation(t *testing.T) {
+	configPath := filepath.Join("..", "..", "..", "..", "..", "services", "identity", "idemix", "testdata", "bls12_381_bbs", "idemix")
+	config, err := crypto.NewConfig(configPath)
+	require.NoError(t, err)
+	pp, err := setup.Setup(32, config.Ipk, math3.BLS12_381_BBS_GURVY)
+	require.NoError(t, err)
+
+	// an owner whose credential is not revoked
+	kvs, err := kvs2.NewInMemory()
+	require.NoError(t, err)
+	keyStore, err := crypto.NewKeyStore(math3.BLS12_381_BBS_GURVY, kvs2.Keystore(kvs))
+	require.NoError(t, err)
+	csp, err := crypto.NewBCCSP(keyStore, math3.BLS12_381_BBS_GURVY)
+	require.NoError(t, err)
+	backendKM, err := idemix.NewKeyManager(config, types.EidNymRhNym, csp)
+	require.NoError(t, err)
+	rsk, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	list := revocation.NewList(config.Ipk)
+	list.Revoke("another revocation handle")
+	require.NoError(t, list.Sign(rsk))
+	verifier, err := revocation.NewVerifier(config.Ipk, math3.BLS12_381_BBS_GURVY, list)
+	require.NoError(t, err)
+	descriptor, err := idemixnym.NewKeyManagerWithRevocation(backendKM, &idemixnymmock.IdentityStoreService{}, verifier).Identity(t.Context(), nil)
+	require.NoError(t, err)
+	owner, err := identity.WrapWithType(idemixnym.IdentityType, descriptor.Identity)
+	require.NoError(t, err)
+	msg := []byte("message")
+	sigma, err := descriptor.Signer.Sign(msg)
+	require.NoError(t, err)
+
+	// no revocation list, the signature is valid
+	d, err := driver.NewDeserializer(pp)
+	require.NoError(t, err)
+	v, err := d.GetOwnerVerifier(t.Context(), owner)
+	require.NoError(t, err)
+	require.NoError(t, v.Verify(msg, sigma))
+
+	// a revocation list of an issuer without a pinned revocation key is rejected
+	raw, err := list.Bytes()
+	require.NoError(t, err)
+	pp.ExtraData[revocation.ExtrasKey] = raw
+	_, err = driver.NewDeserializer(pp)
+	require.ErrorContains(t, err, "no revocation public key pinned")
+
+	// the revocation key is pinned, the signature carries a valid non-revocation proof
+	keys := revocation.Keys{}
+	require.NoError(t, keys.Pin(config.Ipk, list.RevocationPublicKey))
+	pp.ExtraData[revocation.KeysExtrasKey], err = keys.Bytes()
+	require.NoError(t, err)
+	d, err = driver.NewDeserializer(pp)
+	require.NoError(t, err)
+	v, err = d.GetOwnerVerifier(t.Context(), owner)
+	require.NoError(t, err)
+	require.NoError(t, v.Verify(msg, sigma))
+
+	// the credential gets revoked
+	list.Revoke(config.Signer.RevocationHandle)
+	require.NoError(t, list.Sign(rsk))
+	raw, err = list.Bytes()
+	require.NoError(t, err)
+	pp.ExtraData[revocation.ExtrasKey] = raw
+	d, err = driver.NewDeserializer(pp)
+	require.NoError(t, err)
+	v, err = d.GetOwnerVerifier(t.Context(), owner)
+	require.NoError(t, err)
+	require.ErrorContains(t, v.Verify(msg, sigma), "refers to epoch [1], expected [2]")
+
+	// a tampered list is rejected
+	list.RevocationHandles = nil
+	raw, err = list.Bytes()
+	require.NoError(t, err)
+	pp.ExtraData[revocation.ExtrasKey] = raw
+	_, err = driver.NewDeserializer(pp)
+	require.ErrorContains(t, err, "invalid revocation list signature")
+}
@@ -14,10 +14,15 @@ import (
 	"github.com/LFDT-Panurus/panurus/token/services/identity"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/config"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/deserializer"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/hd"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/hybrid"
 	msp2 "github.com/LFDT-Panurus/panurus/token/services/identity/idemix/crypto"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/idemix/revocation"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/idemixnym"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/membership"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/role"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/sigscheme"
+	"github.com/LFDT-Panurus/panurus/token/services/identity/threshold"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/wallet"
 	"github.com/LFDT-Panurus/panurus/token/services/identity/x509"
 	"github.com/LFDT-Panurus/panurus/token/services/logging"
@@ -28,6 +33,8 @@ import (
 
 type BaseWalletServiceFactory struct {
 	PublicParametersDeserializer
+	// Coordinators are the coordinators of the signing ceremonies of threshold identities
+	Coordinators *threshold.Coordinators
 }
 
 // NewWalletService returns a new zkatdlog wallet service.
@@ -55,6 +62,10 @@ func (d *BaseWalletServiceFactory) NewWalletService(
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to open keystore for tms [%s]", tmsID)
 	}
+	walletDB, err := storageProvider.WalletStore(tmsID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get identity storage provider")
+	}
 	identityProvider := identity.NewProvider(logger.Named("identity"), identityDB, deserializerManager, binder, NewEIDRHDeserializer())
 	identityConfig, err := config.NewIdentityConfig(tmsConfig)
 	if err != nil {
@@ -74,7 +85,11 @@ func (d *BaseWalletServiceFactory) NewWalletService(
 	)
 	// owner role
 	// we have one key manager for fabtoken and one for each idemix issuer public key
-	kmps := make([]membership.KeyManagerProvider, 0, len(pp.IdemixIssuerPublicKeys)+1)
+	kmps := make([]membership.KeyManagerProvider, 0, len(pp.IdemixIssuerPublicKeys)+2)
+	revocationList, err := revocation.FromExtras(pp.Extras())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to load idemix revocation list")
+	}
 	for _, key := range pp.IdemixIssuerPublicKeys {
 		keyStore, err := msp2.NewKeyStore(key.Curve, baseKeyStore)
 		if err != nil {
@@ -89,23 +104,32 @@ func (d *BaseWalletServiceFactory) NewWalletService(
 			ignoreRemote,
 			metricsProvider,
 			identityDB,
+			issuerRevocationList(revocationList, key.PublicKey),
 		)
 		kmps = append(kmps, kmp)
 	}
+	// Ed25519 and ML-DSA identities go first, the x509 key manager would load them as verify-only identities
+	sigschemeKMP := sigscheme.NewKeyManagerProvider(identityConfig)
 	keyStore := x509.NewKeyStore(baseKeyStore)
-	kmps = append(kmps, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
+	// owners can also derive a fresh x509 key for every transfer, see the hd package
+	hdKMP := hd.NewKeyManagerProvider(identityConfig, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote), baseKeyStore, walletDB)
+	kmps = append(kmps, hdKMP, sigschemeKMP, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
 
 	newRole, err := roleFactory.NewRole(identity.OwnerRole, true, nil, kmps...)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to create owner role")
 	}
 	roles.Register(identity.OwnerRole, newRole)
-	newRole, err = roleFactory.NewRole(identity.IssuerRole, false, pp.Issuers(), x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
+	// issuers and auditors can also be hybrid identities, pairing an x509 identity with an ML-DSA key,
+	// or threshold identities whose shares are distributed among several nodes
+	hybridKMP := hybrid.NewKeyManagerProvider(identityConfig, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
+	thresholdKMP := threshold.NewKeyManagerProvider(threshold.NewStore(baseKeyStore), d.Coordinators)
+	newRole, err = roleFactory.NewRole(identity.IssuerRole, false, pp.Issuers(), hybridKMP, sigschemeKMP, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote), thresholdKMP)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to create issuer role")
 	}
 	roles.Register(identity.IssuerRole, newRole)
-	newRole, err = roleFactory.NewRole(identity.AuditorRole, false, pp.Auditors(), x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote))
+	newRole, err = roleFactory.NewRole(identity.AuditorRole, false, pp.Auditors(), hybridKMP, sigschemeKMP, x509.NewKeyManagerProvider(identityConfig, keyStore, ignoreRemote), thresholdKMP)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to create auditor role")
 	}
@@ -117,10 +141,6 @@ func (d *BaseWalletServiceFactory) NewWalletService(
 	roles.Register(identity.CertifierRole, newRole)
 
 	// wallet service
-	walletDB, err := storageProvider.WalletStore(tmsID)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get identity storage provider")
-	}
 	deserializer, err := NewDeserializer(pp)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to instantiate the deserializer")
@@ -146,7 +166,8 @@ func NewWalletServiceFactory(storageProvider identity.StorageProvider) core.Name
 	return core.NamedFactory[driver.WalletServiceFactory]{
 		Name: core.DriverIdentifier(v1.DLogNoGHDriverName, v1.ProtocolV1),
 		Driver: &WalletServiceFactory{
-			BaseWalletServiceFactory: &BaseWalletServiceFactory{},
+			// wallet services built by this factory do not sign, no coordinator is ever registered
+			BaseWalletServiceFactory: &BaseWalletServiceFactory{Coordinators: threshold.NewCoordinators()},
 			storageProvider:          storageProvider},
 	}
 }
@@ -174,3 +195,12 @@ func (d *WalletServiceFactory) NewWalletService(tmsConfig driver.Configuration,
 		&disabled.Provider{},
 	)
 }
+
+// issuerRevocationList returns the passed revocation list if it refers to the passed idemix issuer, nil otherwise
+func issuerRevocationList(list *revocation.List, ipk []byte) *revocation.List {
+	if list == nil || !list.Refers(ipk) {
+		return nil
+	}
+
+	return list
+}
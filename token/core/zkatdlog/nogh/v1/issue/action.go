@@ -205,7 +205,7 @@ func (i *Action) Validate() error {
 			return ErrNilOutput
 		}
 	}
-	if i.ProofType != rp.RangeProofType && i.ProofType != rp.CSPRangeProofType {
+	if i.ProofType != rp.RangeProofType && i.ProofType != rp.CSPRangeProofType && i.ProofType != rp.AggregatedRangeProofType && i.ProofType != rp.AggregatedCSPRangeProofType {
 		return ErrInvalidProofType
 	}
 	if len(i.Proof) == 0 {
@@ -260,6 +260,18 @@ func (i *Action) Serialize() ([]byte, error) {
 				CspBasedProof: i.Proof,
 			},
 		}
+	case rp.AggregatedRangeProofType:
+		proof = &actions.Proof{
+			ProofType: &actions.Proof_AggregatedProof{
+				AggregatedProof: i.Proof,
+			},
+		}
+	case rp.AggregatedCSPRangeProofType:
+		proof = &actions.Proof{
+			ProofType: &actions.Proof_AggregatedCspBasedProof{
+				AggregatedCspBasedProof: i.Proof,
+			},
+		}
 	default:
 		return nil, ErrInvalidProofType
 	}
@@ -325,6 +337,12 @@ func (i *Action) Deserialize(raw []byte) error {
 		case *actions.Proof_Proof:
 			i.ProofType = rp.RangeProofType
 			i.Proof = issueAction.Proof.GetProof()
+		case *actions.Proof_AggregatedProof:
+			i.ProofType = rp.AggregatedRangeProofType
+			i.Proof = issueAction.Proof.GetAggregatedProof()
+		case *actions.Proof_AggregatedCspBasedProof:
+			i.ProofType = rp.AggregatedCSPRangeProofType
+			i.Proof = issueAction.Proof.GetAggregatedCspBasedProof()
 		default:
 			return ErrInvalidProofType
 		}
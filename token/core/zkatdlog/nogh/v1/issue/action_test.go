@@ -39,6 +39,24 @@ func TestSerialization(t *testing.T) {
 	err = action3.Deserialize(raw2)
 	require.NoError(t, err, "failed to deserialize a new transfer action")
 	assert.Equal(t, action2, action3, "deserialized action is not equal to the original one")
+
+	// the aggregated proof type survives a round trip
+	action.ProofType = rp.AggregatedRangeProofType
+	raw, err = action.Serialize()
+	require.NoError(t, err, "failed to serialize an action with an aggregated proof")
+	action4 := &Action{}
+	require.NoError(t, action4.Deserialize(raw), "failed to deserialize an action with an aggregated proof")
+	assert.Equal(t, rp.AggregatedRangeProofType, action4.ProofType)
+	assert.Equal(t, action.Proof, action4.Proof)
+
+	// the aggregated CSP proof type survives a round trip
+	action.ProofType = rp.AggregatedCSPRangeProofType
+	raw, err = action.Serialize()
+	require.NoError(t, err, "failed to serialize an action with an aggregated CSP proof")
+	action5 := &Action{}
+	require.NoError(t, action5.Deserialize(raw), "failed to deserialize an action with an aggregated CSP proof")
+	assert.Equal(t, rp.AggregatedCSPRangeProofType, action5.ProofType)
+	assert.Equal(t, action.Proof, action5.Proof)
 }
 
 func TestDeserializeError(t *testing.T) {
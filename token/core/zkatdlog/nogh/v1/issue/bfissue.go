@@ -48,12 +48,24 @@ type BulletProofProver struct {
 	SameType *SameTypeProver
 	// RangeCorrectness is the prover for the range correctness property.
 	RangeCorrectness *bulletproof.RangeCorrectnessProver
+	// proofType is the type of range proof produced by RangeCorrectness.
+	proofType rp.ProofType
 }
 
 // NewBulletProofProver instantiates a BulletProofProver for an issue action using the provided witnesses, tokens, and public parameters.
 func NewBulletProofProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicParams) (*BulletProofProver, error) {
+	return newBulletProofProver(tw, tokens, pp, rp.RangeProofType)
+}
+
+// NewAggregatedProver instantiates a BulletProofProver whose range proofs are aggregated across the issued tokens,
+// using the aggregated range proof parameters.
+func NewAggregatedProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicParams) (*BulletProofProver, error) {
+	return newBulletProofProver(tw, tokens, pp, rp.AggregatedRangeProofType)
+}
+
+func newBulletProofProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicParams, proofType rp.ProofType) (*BulletProofProver, error) {
 	c := math.Curves[pp.Curve]
-	p := &BulletProofProver{}
+	p := &BulletProofProver{proofType: proofType}
 	tokenType := c.HashToZr([]byte(tw[0].Type))
 	commitmentToType := pp.PedersenGenerators[0].Mul(tokenType)
 
@@ -84,6 +96,24 @@ func NewBulletProofProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.Public
 		coms[i].Sub(commitmentToType)
 	}
 	// The range prover takes commitments to values (tokens[i] / commitmentToType).
+	if proofType == rp.AggregatedRangeProofType {
+		p.RangeCorrectness = bulletproof.NewAggregatedRangeCorrectnessProver(
+			coms,
+			values,
+			blindingFactors,
+			pp.PedersenGenerators[1:],
+			pp.AggregatedRangeProofParams.LeftGenerators,
+			pp.AggregatedRangeProofParams.RightGenerators,
+			pp.AggregatedRangeProofParams.P,
+			pp.AggregatedRangeProofParams.Q,
+			pp.AggregatedRangeProofParams.BitLength,
+			pp.AggregatedRangeProofParams.MaxAggregation,
+			c,
+			pp.ExecutorProvider,
+		)
+
+		return p, nil
+	}
 	p.RangeCorrectness = bulletproof.NewRangeCorrectnessProver(
 		coms,
 		values,
@@ -126,5 +156,5 @@ func (p *BulletProofProver) Prove() ([]byte, error) {
 
 // RangeProofType returns the type of range proof used by this prover.
 func (p *BulletProofProver) RangeProofType() rp.ProofType {
-	return rp.RangeProofType
+	return p.proofType
 }
@@ -49,12 +49,24 @@ type CSPBasedProver struct {
 	SameType *SameTypeProver
 	// RangeCorrectness is the prover for the range correctness property.
 	RangeCorrectness *csp.RangeCorrectnessProver
+	// proofType is the type of range proof produced by RangeCorrectness.
+	proofType rp.ProofType
 }
 
 // NewCSPBasedProver instantiates a CSPBasedProver for an issue action using the provided witnesses, tokens, and public parameters.
 func NewCSPBasedProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicParams) (*CSPBasedProver, error) {
+	return newCSPBasedProver(tw, tokens, pp, rp.CSPRangeProofType)
+}
+
+// NewAggregatedCSPBasedProver instantiates a CSPBasedProver whose range proofs are aggregated across the issued tokens,
+// using the aggregated CSP range proof parameters.
+func NewAggregatedCSPBasedProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicParams) (*CSPBasedProver, error) {
+	return newCSPBasedProver(tw, tokens, pp, rp.AggregatedCSPRangeProofType)
+}
+
+func newCSPBasedProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicParams, proofType rp.ProofType) (*CSPBasedProver, error) {
 	c := math.Curves[pp.Curve]
-	p := &CSPBasedProver{}
+	p := &CSPBasedProver{proofType: proofType}
 	tokenType := c.HashToZr([]byte(tw[0].Type))
 	commitmentToType := pp.PedersenGenerators[0].Mul(tokenType)
 
@@ -84,6 +96,22 @@ func NewCSPBasedProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicPar
 		coms[i].Sub(commitmentToType)
 	}
 	// The range prover takes commitments to values (tokens[i] / commitmentToType).
+	if proofType == rp.AggregatedCSPRangeProofType {
+		p.RangeCorrectness = csp.NewAggregatedRangeCorrectnessProver(
+			coms,
+			values,
+			blindingFactors,
+			pp.PedersenGenerators[1:],
+			pp.AggregatedCSPRangeProofParams.LeftGenerators,
+			pp.AggregatedCSPRangeProofParams.RightGenerators,
+			pp.AggregatedCSPRangeProofParams.BitLength,
+			pp.AggregatedCSPRangeProofParams.MaxAggregation,
+			c,
+			pp.ExecutorProvider,
+		).WithTranscriptHeader(pp.AggregatedCSPRangeProofParams.RPTranscriptHeader)
+
+		return p, nil
+	}
 	p.RangeCorrectness = csp.NewRangeCorrectnessProver(
 		coms,
 		values,
@@ -123,7 +151,7 @@ func (p *CSPBasedProver) Prove() ([]byte, error) {
 
 // RangeProofType returns the type of range proof used by this prover.
 func (p *CSPBasedProver) RangeProofType() rp.ProofType {
-	return rp.CSPRangeProofType
+	return p.proofType
 }
 
 // CSPVerifier coordinates the verification of CSP-based zero-knowledge proofs for an issue action.
@@ -150,6 +178,24 @@ func NewCSPVerifier(tokens []*math.G1, pp *v1.PublicParams) *CSPVerifier {
 	return v
 }
 
+// NewAggregatedCSPVerifier instantiates a CSPVerifier for proofs whose range proofs are aggregated
+// across the issued tokens, using the aggregated CSP range proof parameters.
+func NewAggregatedCSPVerifier(tokens []*math.G1, pp *v1.PublicParams) *CSPVerifier {
+	v := &CSPVerifier{}
+	v.SameType = NewSameTypeVerifier(tokens, pp.PedersenGenerators, math.Curves[pp.Curve])
+	v.RangeCorrectness = csp.NewAggregatedRangeCorrectnessVerifier(
+		pp.PedersenGenerators[1:],
+		pp.AggregatedCSPRangeProofParams.LeftGenerators,
+		pp.AggregatedCSPRangeProofParams.RightGenerators,
+		pp.AggregatedCSPRangeProofParams.BitLength,
+		pp.AggregatedCSPRangeProofParams.MaxAggregation,
+		math.Curves[pp.Curve],
+		pp.ExecutorProvider,
+	).WithTranscriptHeader(pp.AggregatedCSPRangeProofParams.RPTranscriptHeader)
+
+	return v
+}
+
 // Verify checks the validity of the zero-knowledge proof for an issue action.
 // It verifies both the same-type property and the range correctness of the issued tokens.
 func (v *CSPVerifier) Verify(proof []byte) error {
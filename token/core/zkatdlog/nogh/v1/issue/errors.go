@@ -85,6 +85,8 @@ var (
 	ErrDeserializeChallengeFailed = errors.New("failed to deserialize challenge")
 	// ErrDeserializeCommitmentToTypeFailed is returned when commitment to type deserialization fails
 	ErrDeserializeCommitmentToTypeFailed = errors.New("failed to deserialize commitment to type")
+	// ErrDeserializeCommitmentFailed is returned when the commitment of a same type proof cannot be deserialized
+	ErrDeserializeCommitmentFailed = errors.New("failed to deserialize commitment")
 	// ErrNilProof is returned when the proof is nil
 	ErrNilProof = errors.New("nil proof")
 	// ErrEmptyProof is returned when the proof is empty
@@ -8,6 +8,7 @@ package issue
 
 import (
 	math "github.com/IBM/mathlib"
+	math2 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/math"
 	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/rp"
 	v1 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/setup"
 	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/token"
@@ -29,9 +30,15 @@ type Verifier interface {
 }
 
 // NewProver returns a new Prover instance based on the public parameters.
-// It selects between BulletProof and CSP-based implementations depending on
-// whether CSPRangeProofParams is set in the public parameters.
+// It selects between aggregated BulletProof, aggregated CSP-based, CSP-based, and BulletProof implementations depending on
+// whether AggregatedRangeProofParams, AggregatedCSPRangeProofParams, or CSPRangeProofParams is set in the public parameters.
 func NewProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicParams) (Prover, error) {
+	if pp.AggregatedRangeProofParams != nil {
+		return NewAggregatedProver(tw, tokens, pp)
+	}
+	if pp.AggregatedCSPRangeProofParams != nil {
+		return NewAggregatedCSPBasedProver(tw, tokens, pp)
+	}
 	if pp.CSPRangeProofParams != nil {
 		return NewCSPBasedProver(tw, tokens, pp)
 	}
@@ -41,18 +48,51 @@ func NewProver(tw []*token.Metadata, tokens []*math.G1, pp *v1.PublicParams) (Pr
 
 // NewVerifier returns a Verifier for the given proofType.
 // It returns ErrProofTypeMismatch if the params sub-struct required by proofType
+// is not populated in pp.
+func NewVerifier(tokens []*math.G1, pp *v1.PublicParams, proofType rp.ProofType) (Verifier, error) {
+	return NewVerifierWithBatch(tokens, pp, proofType, nil)
+}
+
+// NewVerifierWithBatch returns a Verifier for the given proofType.
+// If batch is not nil, the final group equations of the range proofs, and those of the
+// same-type proof when it carries its commitments, are collected in batch instead of being
+// checked, and the proof is valid only if batch.Verify succeeds.
+// It returns ErrProofTypeMismatch if the params sub-struct required by proofType
 // is not populated in pp, preventing an attacker from selecting a verifier whose
 // params sub-struct is nil. Both proof systems may coexist in pp (e.g. during a
 // range-proof migration), so each is checked independently.
-func NewVerifier(tokens []*math.G1, pp *v1.PublicParams, proofType rp.ProofType) (Verifier, error) {
+func NewVerifierWithBatch(tokens []*math.G1, pp *v1.PublicParams, proofType rp.ProofType, batch *math2.BatchVerifier) (Verifier, error) {
 	if !pp.SupportsRangeProofType(proofType) {
 		return nil, errors.Errorf("%w: proof type %d is not available in public parameters",
 			ErrProofTypeMismatch, proofType)
 	}
 
-	if proofType == rp.RangeProofType {
-		return NewBulletProofVerifier(tokens, pp), nil
+	var verifier Verifier
+	switch proofType {
+	case rp.RangeProofType:
+		verifier = NewBulletProofVerifier(tokens, pp)
+	case rp.AggregatedRangeProofType:
+		verifier = NewAggregatedVerifier(tokens, pp)
+	case rp.AggregatedCSPRangeProofType:
+		verifier = NewAggregatedCSPVerifier(tokens, pp)
+	default:
+		verifier = NewCSPVerifier(tokens, pp)
+	}
+	if batch == nil {
+		return verifier, nil
+	}
+	switch v := verifier.(type) {
+	case *BulletProofVerifier:
+		v.SameType.Batch = batch
+		if v.RangeCorrectness != nil {
+			v.RangeCorrectness.Batch = batch
+		}
+	case *CSPVerifier:
+		v.SameType.Batch = batch
+		if v.RangeCorrectness != nil {
+			v.RangeCorrectness.Batch = batch
+		}
 	}
 
-	return NewCSPVerifier(tokens, pp), nil
+	return verifier, nil
 }
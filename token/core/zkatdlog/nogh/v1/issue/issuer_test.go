@@ -31,6 +31,8 @@ func TestProverVerifier(t *testing.T) {
 	}{
 		{"BulletProof", setup},
 		{"CSPProof", setupCSP},
+		{"AggregatedProof", setupAggregated},
+		{"AggregatedCSPProof", setupAggregatedCSP},
 	}
 
 	for _, pt := range proofTypes {
@@ -51,10 +53,12 @@ func TestIssuer(t *testing.T) {
 	proofTypes := []struct {
 		name      string
 		setupFunc func(testing.TB, uint64, math.CurveID) *v1.PublicParams
-		proofType string
+		proofType rp.ProofType
 	}{
-		{"BulletProof", setup, "RangeProofType"},
-		{"CSPProof", setupCSP, "CSPRangeProofType"},
+		{"BulletProof", setup, rp.RangeProofType},
+		{"CSPProof", setupCSP, rp.CSPRangeProofType},
+		{"AggregatedProof", setupAggregated, rp.AggregatedRangeProofType},
+		{"AggregatedCSPProof", setupAggregatedCSP, rp.AggregatedCSPRangeProofType},
 	}
 
 	for _, pt := range proofTypes {
@@ -65,11 +69,7 @@ func TestIssuer(t *testing.T) {
 			require.NoError(t, err)
 
 			// check the proof type is set correctly
-			if pt.proofType == "RangeProofType" {
-				assert.Equal(t, rp.RangeProofType, action.ProofType)
-			} else {
-				assert.Equal(t, rp.CSPRangeProofType, action.ProofType)
-			}
+			assert.Equal(t, pt.proofType, action.ProofType)
 
 			// check the proof
 			coms := make([]*math.G1, len(action.Outputs))
@@ -279,6 +279,38 @@ func setupCSP(tb testing.TB, bits uint64, curveID math.CurveID) *v1.PublicParams
 	return pp
 }
 
+// setupAggregated initializes public parameters with aggregated range proofs for tests and benchmarks
+func setupAggregated(tb testing.TB, bits uint64, curveID math.CurveID) *v1.PublicParams {
+	tb.Helper()
+	pp, err := v1.NewWith(v1.SetupParams{
+		DriverName:     v1.DLogNoGHDriverName,
+		DriverVersion:  v1.ProtocolV1,
+		BitLength:      bits,
+		IdemixIssuerPK: nil,
+		CurveID:        curveID,
+		ProofType:      rp.AggregatedRangeProofType,
+	})
+	require.NoError(tb, err)
+
+	return pp
+}
+
+// setupAggregatedCSP initializes public parameters with aggregated CSP range proofs for tests and benchmarks
+func setupAggregatedCSP(tb testing.TB, bits uint64, curveID math.CurveID) *v1.PublicParams {
+	tb.Helper()
+	pp, err := v1.NewWith(v1.SetupParams{
+		DriverName:     v1.DLogNoGHDriverName,
+		DriverVersion:  v1.ProtocolV1,
+		BitLength:      bits,
+		IdemixIssuerPK: nil,
+		CurveID:        curveID,
+		ProofType:      rp.AggregatedCSPRangeProofType,
+	})
+	require.NoError(tb, err)
+
+	return pp
+}
+
 // prepareInputsForZKIssue creates deterministic token metadata and token
 // commitments that serve as inputs to the prover/verifier in tests.
 func prepareInputsForZKIssue(pp *v1.PublicParams, numOutputs int) ([]*token.Metadata, []*math.G1) {
@@ -386,4 +418,18 @@ func TestNewVerifier_ProofTypeUnavailable(t *testing.T) {
 		require.NoError(t, err,
 			"T-SEC-2D: CSP proof type against dual pp must be accepted")
 	})
+
+	t.Run("BulletProofPP_AggregatedActionType", func(t *testing.T) {
+		pp := setup(t, 32, math.BLS12_381_BBS_GURVY)
+		_, err := issue2.NewVerifier(tokens, pp, rp.AggregatedRangeProofType)
+		require.ErrorIs(t, err, issue2.ErrProofTypeMismatch,
+			"aggregated proof type against BulletProof-only pp must return ErrProofTypeMismatch")
+	})
+
+	t.Run("CSPPP_AggregatedCSPActionType", func(t *testing.T) {
+		pp := setupCSP(t, 32, math.BLS12_381_BBS_GURVY)
+		_, err := issue2.NewVerifier(tokens, pp, rp.AggregatedCSPRangeProofType)
+		require.ErrorIs(t, err, issue2.ErrProofTypeMismatch,
+			"aggregated CSP proof type against CSP-only pp must return ErrProofTypeMismatch")
+	})
 }
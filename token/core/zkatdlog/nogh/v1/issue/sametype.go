@@ -10,6 +10,7 @@ import (
 	math "github.com/IBM/mathlib"
 	"github.com/LFDT-Panurus/panurus/token/core/common/encoding/asn1"
 	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/common"
+	math2 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/math"
 	token2 "github.com/LFDT-Panurus/panurus/token/token"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 )
@@ -25,10 +26,25 @@ type SameType struct {
 	Challenge *math.Zr
 	// CommitmentToType is the commitment to the type being issued.
 	CommitmentToType *math.G1
+	// Commitment is the prover's commitment to the randomness of the proof.
+	// It lets the verifier defer the group equation of the proof into a batch.
+	// Proofs produced before it was introduced do not carry it.
+	Commitment *math.G1
 }
 
 // Serialize marshals the SameType proof into its byte representation.
 func (stp *SameType) Serialize() ([]byte, error) {
+	// proofs without commitment keep their original encoding
+	if stp.Commitment != nil {
+		return asn1.MarshalMath(
+			stp.Type,
+			stp.BlindingFactor,
+			stp.Challenge,
+			stp.CommitmentToType,
+			stp.Commitment,
+		)
+	}
+
 	return asn1.MarshalMath(
 		stp.Type,
 		stp.BlindingFactor,
@@ -59,6 +75,11 @@ func (stp *SameType) Deserialize(bytes []byte) error {
 	if err != nil {
 		return errors.Join(ErrDeserializeCommitmentToTypeFailed, err)
 	}
+	// the commitment is optional
+	stp.Commitment, err = unmarshaller.NextG1()
+	if err != nil {
+		return errors.Join(ErrDeserializeCommitmentFailed, err)
+	}
 
 	return nil
 }
@@ -118,6 +139,7 @@ func (p *SameTypeProver) Prove() (*SameType, error) {
 	proof := &SameType{
 		CommitmentToType: p.CommitmentToType,
 		Challenge:        chal,
+		Commitment:       p.commitment,
 	}
 	// Compute the proof responses.
 	proof.Type = p.Curve.ModMul(chal, tokenType, p.Curve.GroupOrder)
@@ -153,6 +175,9 @@ type SameTypeVerifier struct {
 	Curve *math.Curve
 	// Tokens are the commitments to the issued tokens.
 	Tokens []*math.G1
+	// Batch, if not nil, collects the group equation of the proofs that carry their commitment
+	// instead of checking it. Such a proof is valid only if Batch.Verify succeeds.
+	Batch *math2.BatchVerifier
 }
 
 // NewSameTypeVerifier returns a new SameTypeVerifier instance.
@@ -166,10 +191,17 @@ func NewSameTypeVerifier(tokens []*math.G1, pp []*math.G1, c *math.Curve) *SameT
 
 // Verify checks the validity of the SameType proof.
 func (v *SameTypeVerifier) Verify(proof *SameType) error {
-	// Recompute the commitment to randomness from the proof responses.
-	com := v.PedParams[0].Mul(proof.Type)
-	com.Add(v.PedParams[2].Mul(proof.BlindingFactor))
-	com.Sub(proof.CommitmentToType.Mul(proof.Challenge))
+	if proof.Commitment != nil {
+		if err := math2.CheckElement(proof.Commitment, v.Curve.ID()); err != nil {
+			return errors.Join(ErrVerifySameTypeProofFailed, err)
+		}
+	}
+	// The commitment is recomputed from the proof responses, unless the proof carries it and
+	// the check that it matches the responses can be deferred to the batch.
+	com := proof.Commitment
+	if com == nil || v.Batch == nil {
+		com = v.recomputeCommitment(proof)
+	}
 
 	// Recompute the challenge and check it matches the one in the proof.
 	raw, err := common.GetG1Array([]*math.G1{proof.CommitmentToType, com}).Bytes()
@@ -181,5 +213,34 @@ func (v *SameTypeVerifier) Verify(proof *SameType) error {
 		return ErrInvalidSameTypeProof
 	}
 
+	if proof.Commitment == nil {
+		return nil
+	}
+	if v.Batch != nil {
+		// postpone the check PedParams[0]·Type + PedParams[2]·BlindingFactor - CommitmentToType·Challenge - Commitment == 0
+		negChal := proof.Challenge.Copy()
+		negChal.Neg()
+		minusOne := v.Curve.NewZrFromInt(1)
+		minusOne.Neg()
+		v.Batch.Defer(
+			[]*math.G1{v.PedParams[0], v.PedParams[2], proof.CommitmentToType, proof.Commitment},
+			[]*math.Zr{proof.Type, proof.BlindingFactor, negChal, minusOne},
+		)
+
+		return nil
+	}
+	if !com.Equals(proof.Commitment) {
+		return ErrInvalidSameTypeProof
+	}
+
 	return nil
 }
+
+// recomputeCommitment returns the commitment that makes the responses of proof valid for its challenge.
+func (v *SameTypeVerifier) recomputeCommitment(proof *SameType) *math.G1 {
+	com := v.PedParams[0].Mul(proof.Type)
+	com.Add(v.PedParams[2].Mul(proof.BlindingFactor))
+	com.Sub(proof.CommitmentToType.Mul(proof.Challenge))
+
+	return com
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	math "github.com/IBM/mathlib"
+	math2 "github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/crypto/math"
 	"github.com/LFDT-Panurus/panurus/token/core/zkatdlog/nogh/v1/issue"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,6 +24,52 @@ func TestSameTypeProof(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSameTypeBatch(t *testing.T) {
+	curve := math.Curves[1]
+
+	// the proof carries its commitment and the equation is deferred to the batch
+	prover, verifier := GetSameTypeProverAndVerifier(t)
+	proof, err := prover.Prove()
+	require.NoError(t, err)
+	require.NotNil(t, proof.Commitment)
+	batch, err := math2.NewBatchVerifier(curve)
+	require.NoError(t, err)
+	verifier.Batch = batch
+	require.NoError(t, verifier.Verify(proof))
+	require.Equal(t, 4, batch.Len())
+	require.NoError(t, batch.Verify())
+
+	// the commitment survives serialization, proofs without it keep the original encoding
+	raw, err := proof.Serialize()
+	require.NoError(t, err)
+	proof2 := &issue.SameType{}
+	require.NoError(t, proof2.Deserialize(raw))
+	require.True(t, proof.Commitment.Equals(proof2.Commitment))
+	proof2.Commitment = nil
+	raw, err = proof2.Serialize()
+	require.NoError(t, err)
+	proof3 := &issue.SameType{}
+	require.NoError(t, proof3.Deserialize(raw))
+	require.Nil(t, proof3.Commitment)
+
+	// proofs without commitment are checked immediately
+	batch, err = math2.NewBatchVerifier(curve)
+	require.NoError(t, err)
+	verifier.Batch = batch
+	require.NoError(t, verifier.Verify(proof3))
+	require.Zero(t, batch.Len())
+
+	// a wrong response is rejected in both modes
+	proof.Type = curve.ModAdd(proof.Type, curve.NewZrFromInt(1), curve.GroupOrder)
+	verifier.Batch = nil
+	require.ErrorIs(t, verifier.Verify(proof), issue.ErrInvalidSameTypeProof)
+	batch, err = math2.NewBatchVerifier(curve)
+	require.NoError(t, err)
+	verifier.Batch = batch
+	require.NoError(t, verifier.Verify(proof))
+	require.ErrorIs(t, batch.Verify(), math2.ErrBatchVerificationFailed)
+}
+
 func prepareTokens(t *testing.T, pp []*math.G1) []*math.G1 {
 	t.Helper()
 	curve := math.Curves[1]
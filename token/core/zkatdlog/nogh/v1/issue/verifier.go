@@ -30,6 +30,16 @@ func NewBulletProofVerifier(tokens []*math.G1, pp *v1.PublicParams) *BulletProof
 	return v
 }
 
+// NewAggregatedVerifier instantiates a BulletProofVerifier for proofs whose range proofs are aggregated
+// across the issued tokens, using the aggregated range proof parameters.
+func NewAggregatedVerifier(tokens []*math.G1, pp *v1.PublicParams) *BulletProofVerifier {
+	v := &BulletProofVerifier{}
+	v.SameType = NewSameTypeVerifier(tokens, pp.PedersenGenerators, math.Curves[pp.Curve])
+	v.RangeCorrectness = bulletproof.NewAggregatedRangeCorrectnessVerifier(pp.PedersenGenerators[1:], pp.AggregatedRangeProofParams.LeftGenerators, pp.AggregatedRangeProofParams.RightGenerators, pp.AggregatedRangeProofParams.P, pp.AggregatedRangeProofParams.Q, pp.AggregatedRangeProofParams.BitLength, pp.AggregatedRangeProofParams.MaxAggregation, math.Curves[pp.Curve], pp.ExecutorProvider)
+
+	return v
+}
+
 // Verify checks the validity of the zero-knowledge proof for an issue action.
 // It verifies both the same-type property and the range correctness of the issued tokens.
 func (v *BulletProofVerifier) Verify(proof []byte) error {
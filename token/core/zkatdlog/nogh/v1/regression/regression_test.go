@@ -63,6 +63,9 @@ type ValidationFunc func(actions []any, metadata map[string][]byte) error
 // Notes:
 //   - The testdata is generated by `testdata/zero/generator`. To regenerate vectors,
 //     run that generator and commit the produced `testdata.json` files.
+//   - The `zero` variant uses one Bulletproof range proof per output, the `aggregated`
+//     variant uses aggregated Bulletproof range proofs and the `aggregated_csp` variant
+//     uses aggregated CSP range proofs.
 //   - Each configuration contains 1,024 test cases (4 actions × 4 input/output combos × 64 vectors).
 //   - Each test case includes the token request, transaction ID, metadata, and input tokens.
 func TestRegression(t *testing.T) {
@@ -76,11 +79,12 @@ func TestRegression(t *testing.T) {
 		"64-BN254",
 	}
 
-	root := filepath.Join("testdata", "zero")
-
-	for _, config := range configurations {
-		configDir := filepath.Join(root, config)
-		testRegressionParallel(t, configDir)
+	for _, variant := range []string{"zero", "aggregated", "aggregated_csp"} {
+		root := filepath.Join("testdata", variant)
+		for _, config := range configurations {
+			configDir := filepath.Join(root, config)
+			testRegressionParallel(t, configDir)
+		}
 	}
 }
 